@@ -38,6 +38,9 @@ func assertSolutionsAreEqual(t testing.TB, got *FindSolutionResponse, want *mode
 	if float64(got.Score) != want.Score {
 		t.Errorf("failed to match score: got %f want %f", got.Score, want.Score)
 	}
+	if string(got.Status) != want.Status.String() {
+		t.Errorf("failed to match status: got %s want %s", got.Status, want.Status)
+	}
 	if len(got.Variables) != len(want.Variables) {
 		t.Fatalf("failed to match number of variables: got %d want %d", len(got.Variables), len(want.Variables))
 	}
@@ -96,6 +99,7 @@ func retry(timeout time.Duration, f func() error) error {
 type FindSolutionResponse struct {
 	Uuid graphql.ID
 	Score graphql.Float
+	Status graphql.String
 	Variables []FindSolutionResponseVariable
 }
 
@@ -141,6 +145,7 @@ func simpleJob() *model.NewJob {
 func simpleSolution() *model.Solution {
 	return &model.Solution{
 		Score: 1.0,
+		Status: model.SolutionStatusSatisfiable,
 		Variables: []*model.SolvedVariable{
 			{Name: "var1", Value: true},
 			{Name: "var2", Value: true},
@@ -0,0 +1,110 @@
+package end_to_end_tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	u "github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestJobProgressSubscription(t *testing.T) {
+	cases := []struct {
+		desc string
+		name string
+		input *model.NewJob
+		want *model.Solution
+	}{
+		{ "streams progress ending with the solution", u.NewString(), simpleJob(), simpleSolution() },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.input.Name = tc.name
+			uuid := createJob(t, tc.input)
+			updates := subscribeToProgress(t, uuid)
+			if len(updates) == 0 {
+				t.Fatalf("failed to receive any progress for job %s", uuid.String())
+			}
+			last := updates[len(updates) - 1]
+			if last.State != model.JobStateDone.String() {
+				t.Errorf("failed to match state of last update: got %s want %s", last.State, model.JobStateDone)
+			}
+			if last.Solution == nil {
+				t.Fatalf("failed to end with a solution")
+			}
+			if last.Solution.Score != tc.want.Score {
+				t.Errorf("failed to match score: got %f want %f", last.Solution.Score, tc.want.Score)
+			}
+			if last.Solution.Status != tc.want.Status.String() {
+				t.Errorf("failed to match status: got %s want %s", last.Solution.Status, tc.want.Status)
+			}
+		})
+	}
+}
+
+// subscribeToProgress follows a job over the graphql-ws protocol that the
+// gqlgen websocket transport speaks until the server completes the
+// subscription.
+func subscribeToProgress(t testing.TB, uuid u.UUID) []JobProgressResponse {
+	dialer := websocket.Dialer{Subprotocols: []string{"graphql-ws"}}
+	conn, _, err := dialer.Dial("ws://localhost:8080/query", http.Header{})
+	if err != nil {
+		t.Fatalf("failed to open websocket: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	err = conn.WriteJSON(map[string]interface{}{"type": "connection_init"})
+	if err != nil {
+		t.Fatalf("failed to initialize connection: %v", err)
+	}
+	err = conn.WriteJSON(map[string]interface{}{
+		"id": "1",
+		"type": "start",
+		"payload": map[string]interface{}{
+			"query": `subscription ($uuid: ID!) { jobProgress(uuid: $uuid) { state cycles solution { score status } } }`,
+			"variables": map[string]interface{}{"uuid": uuid.String()},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to start subscription: %v", err)
+	}
+	updates := []JobProgressResponse{}
+	for {
+		var message struct {
+			Type string
+			Payload struct {
+				Data struct {
+					JobProgress JobProgressResponse
+				}
+				Errors []interface{}
+			}
+		}
+		err = conn.ReadJSON(&message)
+		if err != nil {
+			t.Fatalf("failed to read subscription message: %v", err)
+		}
+		switch message.Type {
+		case "data":
+			if len(message.Payload.Errors) > 0 {
+				t.Fatalf("subscription returned errors: %v", message.Payload.Errors)
+			}
+			updates = append(updates, message.Payload.Data.JobProgress)
+		case "error":
+			t.Fatalf("subscription failed: %v", message.Payload.Errors)
+		case "complete":
+			return updates
+		}
+	}
+}
+
+type JobProgressResponse struct {
+	State string
+	Cycles int
+	Solution *struct {
+		Score float64
+		Status string
+	}
+}
@@ -0,0 +1,130 @@
+package dimacs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+// Formula is a CNF formula as written in a DIMACS file. Literals are the
+// file's own integers: n for variable n and -n for its negation.
+type Formula struct {
+	Variables int
+	Clauses [][]int
+}
+
+// Parse reads a DIMACS CNF file. Comment lines may appear anywhere, clauses may
+// span lines and the "%" line that ends SATLIB files stops parsing. Every error
+// names the line it was found on.
+func Parse(reader io.Reader) (*Formula, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64 * 1024), 1024 * 1024)
+	var formula *Formula
+	declaredClauses := 0
+	clause := []int{}
+	clauseLine := 0
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "c") {
+			continue
+		}
+		if strings.HasPrefix(line, "%") {
+			break
+		}
+		if strings.HasPrefix(line, "p") {
+			if formula != nil {
+				return nil, fmt.Errorf("line %d: duplicate problem line", lineNumber)
+			}
+			var err error
+			formula, declaredClauses, err = parseProblemLine(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+			continue
+		}
+		if formula == nil {
+			return nil, fmt.Errorf("line %d: clause before problem line \"p cnf <variables> <clauses>\"", lineNumber)
+		}
+		for _, field := range strings.Fields(line) {
+			literal, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid literal %q", lineNumber, field)
+			}
+			if len(clause) == 0 {
+				clauseLine = lineNumber
+			}
+			if literal == 0 {
+				if len(clause) == 0 {
+					return nil, fmt.Errorf("line %d: empty clause", lineNumber)
+				}
+				formula.Clauses = append(formula.Clauses, clause)
+				clause = []int{}
+				continue
+			}
+			if literal > formula.Variables || -literal > formula.Variables {
+				return nil, fmt.Errorf("line %d: literal %d exceeds the %d declared variables", lineNumber, literal, formula.Variables)
+			}
+			clause = append(clause, literal)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("line %d: %v", lineNumber + 1, err)
+	}
+	if formula == nil {
+		return nil, fmt.Errorf("line %d: missing problem line \"p cnf <variables> <clauses>\"", lineNumber)
+	}
+	if len(clause) > 0 {
+		return nil, fmt.Errorf("line %d: clause is not terminated by 0", clauseLine)
+	}
+	if len(formula.Clauses) != declaredClauses {
+		return nil, fmt.Errorf("line %d: found %d clauses but the problem line declares %d", lineNumber, len(formula.Clauses), declaredClauses)
+	}
+	return formula, nil
+}
+
+func parseProblemLine(line string) (*Formula, int, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 4 || fields[0] != "p" || fields[1] != "cnf" {
+		return nil, 0, fmt.Errorf("invalid problem line %q, expected \"p cnf <variables> <clauses>\"", line)
+	}
+	variables, err := strconv.Atoi(fields[2])
+	if err != nil || variables < 0 {
+		return nil, 0, fmt.Errorf("invalid variable count %q", fields[2])
+	}
+	clauses, err := strconv.Atoi(fields[3])
+	if err != nil || clauses < 0 {
+		return nil, 0, fmt.Errorf("invalid clause count %q", fields[3])
+	}
+	return &Formula{Variables: variables, Clauses: [][]int{}}, clauses, nil
+}
+
+// VariableName is the name a job uses for DIMACS variable n.
+func VariableName(n int) string {
+	return fmt.Sprintf("x%d", n)
+}
+
+// NewClauses converts the formula into job input.
+func (f *Formula) NewClauses() []*model.NewClause {
+	clauses := []*model.NewClause{}
+	for _, literals := range f.Clauses {
+		clause := &model.NewClause{Literals: []*model.NewVariable{}}
+		for _, literal := range literals {
+			clause.Literals = append(clause.Literals, newVariable(literal))
+		}
+		clauses = append(clauses, clause)
+	}
+	return clauses
+}
+
+func newVariable(literal int) *model.NewVariable {
+	if literal < 0 {
+		return &model.NewVariable{Name: VariableName(-literal), Negated: true}
+	}
+	return &model.NewVariable{Name: VariableName(literal), Negated: false}
+}
@@ -0,0 +1,137 @@
+package dimacs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		desc string
+		cnf string
+		wantVariables int
+		wantClauses [][]int
+	}{
+		{ "single clause", "p cnf 3 1\n1 -2 3 0\n", 3, [][]int{ { 1, -2, 3 } } },
+		{ "comments and blank lines are skipped", "c a comment\n\np cnf 3 2\nc another\n1 2 3 0\n\n-1 -2 -3 0\n", 3, [][]int{ { 1, 2, 3 }, { -1, -2, -3 } } },
+		{ "clauses may span and share lines", "p cnf 4 3\n1 2\n3 0 -4 0 2\n-1 0\n", 4, [][]int{ { 1, 2, 3 }, { -4 }, { 2, -1 } } },
+		{ "SATLIB trailer is ignored", "p cnf 3 1\n 1 -2 3 0\n%\n0\n\n", 3, [][]int{ { 1, -2, 3 } } },
+		{ "no clauses", "p cnf 0 0\n", 0, [][]int{} },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// act
+			got, err := Parse(strings.NewReader(tc.cnf))
+
+			// assert
+			if err != nil {
+				t.Fatalf("returned an error: %v", err)
+			}
+			if got.Variables != tc.wantVariables {
+				t.Errorf("wrong Variables value: got %d want %d", got.Variables, tc.wantVariables)
+			}
+			assertClausesAreEqual(t, got.Clauses, tc.wantClauses)
+		})
+	}
+}
+
+func TestParseWhenGivenInvalidInput(t *testing.T) {
+	cases := []struct {
+		desc string
+		cnf string
+		want string
+	}{
+		{ "error on missing problem line", "c nothing here\n", "line 1: missing problem line \"p cnf <variables> <clauses>\"" },
+		{ "error on clause before problem line", "1 2 3 0\np cnf 3 1\n", "line 1: clause before problem line \"p cnf <variables> <clauses>\"" },
+		{ "error on wrong format", "p dnf 3 1\n1 2 3 0\n", "line 1: invalid problem line \"p dnf 3 1\", expected \"p cnf <variables> <clauses>\"" },
+		{ "error on invalid variable count", "p cnf three 1\n1 2 3 0\n", "line 1: invalid variable count \"three\"" },
+		{ "error on invalid clause count", "p cnf 3 -1\n", "line 1: invalid clause count \"-1\"" },
+		{ "error on duplicate problem line", "p cnf 3 1\np cnf 3 1\n1 2 3 0\n", "line 2: duplicate problem line" },
+		{ "error on invalid literal", "p cnf 3 1\n1 x2 3 0\n", "line 2: invalid literal \"x2\"" },
+		{ "error on undeclared variable", "p cnf 3 1\n1 -4 3 0\n", "line 2: literal -4 exceeds the 3 declared variables" },
+		{ "error on empty clause", "p cnf 3 2\n1 2 3 0\n0\n", "line 3: empty clause" },
+		{ "error on unterminated clause", "p cnf 3 2\n1 2 3 0\n-1\n-2\n", "line 3: clause is not terminated by 0" },
+		{ "error on wrong clause count", "p cnf 3 2\n1 2 3 0\n", "line 2: found 1 clauses but the problem line declares 2" },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// act
+			_, err := Parse(strings.NewReader(tc.cnf))
+
+			// assert
+			if err == nil {
+				t.Fatal("failed to return error")
+			}
+			if err.Error() != tc.want {
+				t.Errorf("wrong error: got '%s' want '%s'", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+func TestNewClauses(t *testing.T) {
+	cases := []struct {
+		desc string
+		cnf string
+		want []*model.NewClause
+	}{
+		{ "literals become named variables", "p cnf 3 1\n1 -2 3 0\n", []*model.NewClause{
+			newClause(newVariable(1), newVariable(-2), newVariable(3)),
+		} },
+		{ "clauses keep their width", "p cnf 4 3\n-1 0\n1 2 0\n1 -2 3 -4 0\n", []*model.NewClause{
+			newClause(newVariable(-1)),
+			newClause(newVariable(1), newVariable(2)),
+			newClause(newVariable(1), newVariable(-2), newVariable(3), newVariable(-4)),
+		} },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			formula, err := Parse(strings.NewReader(tc.cnf))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// act
+			got := formula.NewClauses()
+
+			// assert
+			if len(got) != len(tc.want) {
+				t.Fatalf("wrong number of clauses: got %d want %d", len(got), len(tc.want))
+			}
+			for index, clause := range tc.want {
+				if len(got[index].Literals) != len(clause.Literals) {
+					t.Fatalf("clause %d has %d literals want %d", index, len(got[index].Literals), len(clause.Literals))
+				}
+				for i, literal := range clause.Literals {
+					if *got[index].Literals[i] != *literal {
+						t.Errorf("clause %d literal %d got %v want %v", index, i, *got[index].Literals[i], *literal)
+					}
+				}
+			}
+		})
+	}
+}
+
+func assertClausesAreEqual(t testing.TB, got [][]int, want [][]int) {
+	if len(got) != len(want) {
+		t.Fatalf("wrong number of clauses: got %d want %d", len(got), len(want))
+	}
+	for index, clause := range want {
+		if len(got[index]) != len(clause) {
+			t.Fatalf("clause %d got %v want %v", index, got[index], clause)
+		}
+		for i, literal := range clause {
+			if got[index][i] != literal {
+				t.Errorf("clause %d got %v want %v", index, got[index], clause)
+				break
+			}
+		}
+	}
+}
+
+func newClause(literals ...*model.NewVariable) *model.NewClause {
+	return &model.NewClause{ Literals: literals }
+}
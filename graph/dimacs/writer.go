@@ -0,0 +1,108 @@
+package dimacs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+const literalsPerLine = 20
+
+// WriteSolution writes a solution in the SAT competition output format: an
+// "s" line with the answer followed, for satisfiable solutions, by "v" lines
+// listing every variable as a signed integer and terminated by 0. Statuses the
+// competition has no answer for, such as TIMEOUT, are reported as UNKNOWN.
+func WriteSolution(writer io.Writer, solution *model.Solution) error {
+	buffer := bufio.NewWriter(writer)
+	fmt.Fprintf(buffer, "c solution %s\n", solution.Uuid.String())
+	fmt.Fprintf(buffer, "c status %s\n", solution.Status.String())
+	fmt.Fprintf(buffer, "s %s\n", answer(solution.Status))
+	if solution.Status == model.SolutionStatusSatisfiable {
+		literals := solutionLiterals(solution.Variables)
+		for start := 0; start < len(literals); start += literalsPerLine {
+			end := start + literalsPerLine
+			if end > len(literals) {
+				end = len(literals)
+			}
+			fmt.Fprintf(buffer, "v %s\n", strings.Join(literals[start:end], " "))
+		}
+		fmt.Fprintln(buffer, "v 0")
+	}
+	return buffer.Flush()
+}
+
+// FormatSolution returns the output of WriteSolution as a string.
+func FormatSolution(solution *model.Solution) string {
+	var builder strings.Builder
+	WriteSolution(&builder, solution)
+	return builder.String()
+}
+
+func answer(status model.SolutionStatus) string {
+	switch status {
+	case model.SolutionStatusSatisfiable, model.SolutionStatusUnsatisfiable:
+		return status.String()
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func solutionLiterals(variables []*model.SolvedVariable) []string {
+	numbers := variableNumbers(variables)
+	sorted := make([]*model.SolvedVariable, len(variables))
+	copy(sorted, variables)
+	sort.Slice(sorted, func(i, j int) bool {
+		return numbers[sorted[i].Name] < numbers[sorted[j].Name]
+	})
+	literals := []string{}
+	for _, variable := range sorted {
+		literal := numbers[variable.Name]
+		if !variable.Value {
+			literal = -literal
+		}
+		literals = append(literals, strconv.Itoa(literal))
+	}
+	return literals
+}
+
+// variableNumbers maps names produced by VariableName back to the integers
+// they had in the imported file, so an imported job round-trips. Variables
+// named some other way, as createJob allows, have no such integer and are
+// numbered after the highest imported one, in sorted name order.
+func variableNumbers(variables []*model.SolvedVariable) map[string]int {
+	numbers := map[string]int{}
+	highest := 0
+	others := []string{}
+	for _, variable := range variables {
+		n, ok := variableNumber(variable.Name)
+		if !ok {
+			others = append(others, variable.Name)
+			continue
+		}
+		numbers[variable.Name] = n
+		if n > highest {
+			highest = n
+		}
+	}
+	sort.Strings(others)
+	for index, name := range others {
+		numbers[name] = highest + index + 1
+	}
+	return numbers
+}
+
+func variableNumber(name string) (int, bool) {
+	if !strings.HasPrefix(name, "x") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(name[1:])
+	if err != nil || n < 1 || VariableName(n) != name {
+		return 0, false
+	}
+	return n, true
+}
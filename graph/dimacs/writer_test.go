@@ -0,0 +1,143 @@
+package dimacs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	u "github.com/google/uuid"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestFormatSolution(t *testing.T) {
+	uuid := u.MustParse("b2312d3c-b09d-4d35-9528-a70104c70738")
+	header := "c solution b2312d3c-b09d-4d35-9528-a70104c70738\n"
+	cases := []struct {
+		desc string
+		solution *model.Solution
+		want string
+	}{
+		{ "imported variables keep their numbers", solution(uuid, model.SolutionStatusSatisfiable,
+			&model.SolvedVariable{ Name: "x10", Value: true },
+			&model.SolvedVariable{ Name: "x2", Value: false },
+			&model.SolvedVariable{ Name: "x7", Value: true },
+		), header + "c status SATISFIABLE\ns SATISFIABLE\nv -2 7 10\nv 0\n" },
+		{ "other names are numbered after imported ones in sorted order", solution(uuid, model.SolutionStatusSatisfiable,
+			&model.SolvedVariable{ Name: "v2", Value: true },
+			&model.SolvedVariable{ Name: "x1", Value: true },
+			&model.SolvedVariable{ Name: "v1", Value: false },
+		), header + "c status SATISFIABLE\ns SATISFIABLE\nv 1 -2 3\nv 0\n" },
+		{ "unsatisfiable has no values", solution(uuid, model.SolutionStatusUnsatisfiable,
+			&model.SolvedVariable{ Name: "x1", Value: true },
+		), header + "c status UNSATISFIABLE\ns UNSATISFIABLE\n" },
+		{ "timeout is unknown", solution(uuid, model.SolutionStatusTimeout,
+			&model.SolvedVariable{ Name: "x1", Value: true },
+		), header + "c status TIMEOUT\ns UNKNOWN\n" },
+		{ "long assignments are split across lines", wideSolution(uuid, 25),
+			header + "c status SATISFIABLE\ns SATISFIABLE\n" +
+			"v 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20\nv 21 22 23 24 25\nv 0\n" },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// act
+			got := FormatSolution(tc.solution)
+
+			// assert
+			if got != tc.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatSolutionRoundTrips(t *testing.T) {
+	cases := []struct {
+		desc string
+		cnf string
+		names []string
+		want map[string]int
+	}{
+		{ "imported job keeps the file's numbers", "p cnf 5 2\n1 -3 0\n5 0\n", nil,
+			map[string]int{ "x1": 1, "x3": 3, "x5": 5 } },
+		{ "names not from a file are numbered in sorted order", "", []string{ "gamma", "alpha", "beta" },
+			map[string]int{ "alpha": 1, "beta": 2, "gamma": 3 } },
+		{ "names not from a file follow the imported numbers", "p cnf 4 1\n-4 2 0\n", []string{ "b", "a" },
+			map[string]int{ "x2": 2, "x4": 4, "a": 5, "b": 6 } },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			names := append([]string{}, tc.names...)
+			if tc.cnf != "" {
+				formula, err := Parse(strings.NewReader(tc.cnf))
+				if err != nil {
+					t.Fatalf("failed to parse: %v", err)
+				}
+				for _, clause := range formula.NewClauses() {
+					for _, literal := range clause.Literals {
+						names = append(names, literal.Name)
+					}
+				}
+			}
+			variables := []*model.SolvedVariable{}
+			for index, name := range names {
+				variables = append(variables, &model.SolvedVariable{ Name: name, Value: index % 2 == 0 })
+			}
+
+			// act
+			got := solutionValues(t, FormatSolution(solution(u.New(), model.SolutionStatusSatisfiable, variables...)))
+
+			// assert
+			if len(got) != len(tc.want) {
+				t.Fatalf("wrong number of values: got %d want %d", len(got), len(tc.want))
+			}
+			for _, variable := range variables {
+				value, found := got[tc.want[variable.Name]]
+				if !found || value != variable.Value {
+					t.Errorf("variable %s: got (%t %t) at %d want %t", variable.Name, value, found, tc.want[variable.Name], variable.Value)
+				}
+			}
+		})
+	}
+}
+
+// solutionValues reads the value of every variable number back from the "v"
+// lines of a solution.
+func solutionValues(t testing.TB, output string) map[int]bool {
+	values := map[int]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(line, "v ") {
+			continue
+		}
+		for _, field := range strings.Fields(line)[1:] {
+			literal, err := strconv.Atoi(field)
+			if err != nil {
+				t.Fatalf("invalid literal %q", field)
+			}
+			if literal > 0 {
+				values[literal] = true
+			} else if literal < 0 {
+				values[-literal] = false
+			}
+		}
+	}
+	return values
+}
+
+func solution(uuid u.UUID, status model.SolutionStatus, variables ...*model.SolvedVariable) *model.Solution {
+	return &model.Solution{
+		Uuid: uuid,
+		Variables: variables,
+		Status: status,
+	}
+}
+
+func wideSolution(uuid u.UUID, width int) *model.Solution {
+	variables := []*model.SolvedVariable{}
+	for n := width; n >= 1; n-- {
+		variables = append(variables, &model.SolvedVariable{ Name: fmt.Sprintf("x%d", n), Value: true })
+	}
+	return solution(uuid, model.SolutionStatusSatisfiable, variables...)
+}
@@ -1,32 +1,62 @@
 package factories
 
 import (
+	"fmt"
+	"time"
+
 	u "github.com/google/uuid"
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
 )
 
 type JobFactory struct {
+	DefaultSolver model.SolverType
 }
 
-func (f *JobFactory) CreateJob(newJob *model.NewJob) *model.Job {
+// CreateJob returns an error when a clause has no literals or mixes the
+// literals list with the older var1, var2 and var3 fields.
+func (f *JobFactory) CreateJob(newJob *model.NewJob) (*model.Job, error) {
 	job := &model.Job{
-		Name:    newJob.Name,
-		Clauses: []*model.Clause{},
-		Done:    false,
-		Uuid:    u.New(),
+		Name:       newJob.Name,
+		Clauses:    []*model.Clause{},
+		Done:       false,
+		Uuid:       u.New(),
+		Solver:     f.solver(newJob.Solver),
+		Parameters: createParameters(newJob.Parameters),
+		State:      model.JobStateQueued,
+		CreatedAt:  time.Now(),
 	}
-	for _, clause := range newJob.Clauses {
-		job.Clauses = append(job.Clauses, createClause(clause))
+	for index, clause := range newJob.Clauses {
+		created, err := createClause(clause)
+		if err != nil {
+			return nil, fmt.Errorf("clause %d: %v", index, err)
+		}
+		job.Clauses = append(job.Clauses, created)
 	}
-	return job
+	return job, nil
 }
 
-func createClause(clause *model.NewClause) *model.Clause {
-	return &model.Clause{
-		Var1: createVariable(clause.Var1),
-		Var2: createVariable(clause.Var2),
-		Var3: createVariable(clause.Var3),
+func createClause(clause *model.NewClause) (*model.Clause, error) {
+	if clause == nil {
+		return nil, fmt.Errorf("clause is null")
+	}
+	threeFields := clause.Var1 != nil || clause.Var2 != nil || clause.Var3 != nil
+	literals := clause.Literals
+	if literals == nil {
+		if clause.Var1 == nil || clause.Var2 == nil || clause.Var3 == nil {
+			return nil, fmt.Errorf("var1, var2 and var3 are required when literals is not given")
+		}
+		literals = []*model.NewVariable{clause.Var1, clause.Var2, clause.Var3}
+	} else if threeFields {
+		return nil, fmt.Errorf("give either literals or var1, var2 and var3, not both")
+	}
+	if len(literals) == 0 {
+		return nil, fmt.Errorf("clause has no literals")
 	}
+	created := &model.Clause{Literals: []*model.Variable{}}
+	for _, literal := range literals {
+		created.Literals = append(created.Literals, createVariable(literal))
+	}
+	return created, nil
 }
 
 func createVariable(variable *model.NewVariable) *model.Variable {
@@ -35,3 +65,45 @@ func createVariable(variable *model.NewVariable) *model.Variable {
 		Name:    variable.Name,
 	}
 }
+
+func (f *JobFactory) solver(solver *model.SolverType) model.SolverType {
+	if solver != nil {
+		return *solver
+	}
+	if f.DefaultSolver != "" {
+		return f.DefaultSolver
+	}
+	return model.SolverTypeGenetic
+}
+
+func createParameters(parameters *model.NewSolverParameters) *model.SolverParameters {
+	if parameters == nil {
+		return nil
+	}
+	return &model.SolverParameters{
+		PopulationSize:        parameters.PopulationSize,
+		TimeLimit:             parameters.TimeLimit,
+		MutationRate:          parameters.MutationRate,
+		Seed:                  parameters.Seed,
+		Noise:                 parameters.Noise,
+		MaxFlips:              parameters.MaxFlips,
+		MaxTries:              parameters.MaxTries,
+		Selection:             parameters.Selection,
+		Crossover:             parameters.Crossover,
+		Mutation:              parameters.Mutation,
+		TournamentSize:        parameters.TournamentSize,
+		MaxGenerations:        parameters.MaxGenerations,
+		Islands:               parameters.Islands,
+		MigrationInterval:     parameters.MigrationInterval,
+		Migrants:              parameters.Migrants,
+		Threads:               parameters.Threads,
+		RefinementFlips:       parameters.RefinementFlips,
+		RefinementProbability: parameters.RefinementProbability,
+		CoolingSchedule:       parameters.CoolingSchedule,
+		InitialTemperature:    parameters.InitialTemperature,
+		FinalTemperature:      parameters.FinalTemperature,
+		TabuTenure:            parameters.TabuTenure,
+		Aspiration:            parameters.Aspiration,
+		Portfolio:             parameters.Portfolio,
+	}
+}
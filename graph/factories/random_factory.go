@@ -26,3 +26,15 @@ func (f *ZeroRandomFactory) Build() *rand.Rand {
 	}
 	return f.staticRandom
 }
+
+type SeededRandomFactory struct {
+	Seed int64
+	staticRandom *rand.Rand
+}
+
+func (f *SeededRandomFactory) Build() *rand.Rand {
+	if f.staticRandom == nil {
+		f.staticRandom = rand.New(rand.NewSource(f.Seed))
+	}
+	return f.staticRandom
+}
@@ -9,27 +9,26 @@ import (
 type SolutionFactory struct {
 }
 
-func (f *SolutionFactory) ConstructSolution(variables map[string]bool, job *model.Job, cycles int, elapsed time.Duration) *model.Solution {
+func (f *SolutionFactory) ConstructSolution(variables map[string]bool, job *model.Job, cycles int, elapsed time.Duration, status model.SolutionStatus) *model.Solution {
 	return &model.Solution{
 		Uuid: job.Uuid,
 		Variables: f.packageSolvedVariables(variables),
-		Score: f.score(variables, job.Clauses),
+		Score: job.Score(variables),
 		Cycles: cycles,
 		Elapsed: elapsed,
+		Status: status,
+		History: []*model.HistoryPoint{},
 	}
 }
 
-func (f *SolutionFactory) score(variables map[string]bool, clauses []*model.Clause) float64 {
-	correct := 0
-	for _, c := range clauses {
-		value1 := variables[c.Var1.Name] != c.Var1.Negated
-		value2 := variables[c.Var2.Name] != c.Var2.Negated
-		value3 := variables[c.Var3.Name] != c.Var3.Negated
-		if value1 || value2 || value3 {
-			correct++
-		}
+func (f *SolutionFactory) ConstructEmptySolution(job *model.Job, elapsed time.Duration, status model.SolutionStatus) *model.Solution {
+	return &model.Solution{
+		Uuid: job.Uuid,
+		Variables: []*model.SolvedVariable{},
+		Elapsed: elapsed,
+		Status: status,
+		History: []*model.HistoryPoint{},
 	}
-	return float64(correct) / float64(len(clauses))
 }
 
 func (f *SolutionFactory) packageSolvedVariables(variables map[string]bool) []*model.SolvedVariable {
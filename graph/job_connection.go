@@ -0,0 +1,77 @@
+package graph
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	u "github.com/google/uuid"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize = 100
+	cursorPrefix = "job:"
+)
+
+// Jobs returns a Relay connection over the stored jobs. Cursors are opaque to
+// clients but only wrap the uuid of a job, which the repository uses to find
+// where the next page starts.
+func (d *JobDispatcher) Jobs(first *int, after *string, filter *model.JobFilter, order *model.JobOrder) (*model.JobConnection, error) {
+	limit := defaultPageSize
+	if first != nil {
+		limit = *first
+	}
+	if limit < 0 || limit > maxPageSize {
+		return nil, fmt.Errorf("first must be between 0 and %d, got %d", maxPageSize, limit)
+	}
+	var afterUuid *u.UUID
+	if after != nil {
+		uuid, err := decodeCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+		afterUuid = &uuid
+	}
+	jobs, err := d.jobRepository.FindJobs(filter, order, afterUuid, limit + 1)
+	if err != nil {
+		return nil, err
+	}
+	count, err := d.jobRepository.CountJobs(filter)
+	if err != nil {
+		return nil, err
+	}
+	connection := &model.JobConnection{
+		Edges: []*model.JobEdge{},
+		PageInfo: &model.PageInfo{HasNextPage: len(jobs) > limit},
+		TotalCount: count,
+	}
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	for _, job := range jobs {
+		connection.Edges = append(connection.Edges, &model.JobEdge{Cursor: encodeCursor(job.Uuid), Node: job})
+	}
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.StartCursor = &connection.Edges[0].Cursor
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges) - 1].Cursor
+	}
+	return connection, nil
+}
+
+func encodeCursor(uuid u.UUID) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + uuid.String()))
+}
+
+func decodeCursor(cursor string) (u.UUID, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), cursorPrefix) {
+		return u.UUID{}, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	uuid, err := u.Parse(strings.TrimPrefix(string(decoded), cursorPrefix))
+	if err != nil {
+		return u.UUID{}, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return uuid, nil
+}
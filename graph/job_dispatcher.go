@@ -1,6 +1,13 @@
 package graph
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
@@ -9,43 +16,251 @@ import (
 )
 
 type JobDispatcher struct {
-	solver solvers.Solver
+	registry *solvers.Registry
 	jobRepository repositories.JobRepository
 	solutionRepository repositories.SolutionRepository
 	jobFactory *factories.JobFactory
+	solutionFactory *factories.SolutionFactory
+	queue []*queuedJob
+	running map[uuid.UUID]*runningJob
+	progress map[uuid.UUID]*model.JobProgress
+	subscribers map[uuid.UUID][]chan *model.JobProgress
+	m sync.Mutex
+	available *sync.Cond
+}
+
+type queuedJob struct {
+	job *model.Job
+	solver solvers.Solver
 }
 
+// runningJob stops the solver of a job and tells when its final state has
+// been recorded.
+type runningJob struct {
+	cancel context.CancelFunc
+	finished chan bool
+}
+
+// NewJobDispatcher starts workers goroutines that take jobs off a FIFO queue,
+// so at most that many jobs are solved at the same time.
 func NewJobDispatcher(
-	solver solvers.Solver,
+	registry *solvers.Registry,
 	jobRepository repositories.JobRepository,
 	solutionRepository repositories.SolutionRepository,
 	jobFactory *factories.JobFactory,
+	solutionFactory *factories.SolutionFactory,
+	workers int,
 ) *JobDispatcher {
-	return &JobDispatcher{
-		solver: solver,
+	d := &JobDispatcher{
+		registry: registry,
 		jobRepository: jobRepository,
 		solutionRepository: solutionRepository,
 		jobFactory: jobFactory,
+		solutionFactory: solutionFactory,
+		queue: []*queuedJob{},
+		running: map[uuid.UUID]*runningJob{},
+		progress: map[uuid.UUID]*model.JobProgress{},
+		subscribers: map[uuid.UUID][]chan *model.JobProgress{},
+	}
+	d.available = sync.NewCond(&d.m)
+	for i := 0; i < workers; i++ {
+		go d.work()
+	}
+	return d
+}
+
+func (d *JobDispatcher) DispatchJob(newJob *model.NewJob) (*model.Job, error) {
+	job, err := d.jobFactory.CreateJob(newJob)
+	if err != nil {
+		return nil, err
+	}
+	solver, err := d.registry.Build(job.Solver, job.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	err = d.jobRepository.InsertJob(job)
+	if err != nil {
+		return nil, err
+	}
+	d.enqueue(job, solver)
+	return job, nil
+}
+
+// RecoverJobs puts jobs left queued or running by a previous server process
+// back on the queue, running ones first since they were started earlier. A
+// job whose solver can no longer be built is finished with an ERROR solution.
+func (d *JobDispatcher) RecoverJobs() error {
+	for _, state := range []model.JobState{model.JobStateRunning, model.JobStateQueued} {
+		jobs, err := d.jobRepository.FindJobsByState(state)
+		if err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			err = d.recoverJob(job)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *JobDispatcher) recoverJob(job *model.Job) error {
+	solver, err := d.registry.Build(job.Solver, job.Parameters)
+	if err != nil {
+		log.Printf("unable to recover job %s: %v", job.Uuid.String(), err)
+		err = d.solutionRepository.InsertSolution(d.solutionFactory.ConstructEmptySolution(job, 0, model.SolutionStatusError))
+		if err != nil {
+			return err
+		}
+		return d.jobRepository.MarkDone(job)
+	}
+	err = d.jobRepository.UpdateState(job, model.JobStateQueued)
+	if err != nil {
+		return err
+	}
+	d.enqueue(job, solver)
+	return nil
+}
+
+func (d *JobDispatcher) enqueue(job *model.Job, solver solvers.Solver) {
+	d.m.Lock()
+	d.queue = append(d.queue, &queuedJob{job: job, solver: solver})
+	d.m.Unlock()
+	d.available.Signal()
+}
+
+// dequeue waits for the next job and registers it as running, returning the
+// context that CancelJob uses to stop its solver.
+func (d *JobDispatcher) dequeue() (*queuedJob, context.Context) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	for len(d.queue) == 0 {
+		d.available.Wait()
 	}
+	next := d.queue[0]
+	d.queue = d.queue[1:]
+	ctx, cancel := context.WithCancel(context.Background())
+	d.running[next.job.Uuid] = &runningJob{cancel: cancel, finished: make(chan bool)}
+	return next, ctx
 }
 
-func (d *JobDispatcher) DispatchJob(newJob *model.NewJob) *model.Job {
-	job := d.jobFactory.CreateJob(newJob)
-	d.jobRepository.InsertJob(job)
-	go d.dispatchJobAsync(job)
-	return job
+func (d *JobDispatcher) work() {
+	for {
+		next, ctx := d.dequeue()
+		err := d.jobRepository.UpdateState(next.job, model.JobStateRunning)
+		if err != nil {
+			log.Printf("unable to record job %s as %s: %v", next.job.Uuid.String(), model.JobStateRunning, err)
+		}
+		d.publishProgress(&model.JobProgress{Uuid: next.job.Uuid, State: model.JobStateRunning})
+		d.runJob(ctx, next.job, next.solver)
+		d.m.Lock()
+		running := d.running[next.job.Uuid]
+		running.cancel()
+		delete(d.running, next.job.Uuid)
+		d.m.Unlock()
+		close(running.finished)
+	}
 }
 
-func (d *JobDispatcher) dispatchJobAsync(job *model.Job) {
-	solution := d.solver.Solve(job)
-	d.solutionRepository.InsertSolution(solution)
-	d.jobRepository.MarkDone(job)
+func (d *JobDispatcher) runJob(ctx context.Context, job *model.Job, solver solvers.Solver) {
+	start := time.Now()
+	reporter := &jobProgressReporter{dispatcher: d, job: job, start: start, last: start}
+	solution := d.solve(solvers.WithProgressReporter(ctx, reporter), job, solver)
+	err := d.solutionRepository.InsertSolution(solution)
+	if err != nil {
+		log.Printf("unable to store solution of job %s: %v", job.Uuid.String(), err)
+	}
+	state := model.JobStateDone
+	if solution.Status == model.SolutionStatusCancelled {
+		state = model.JobStateCancelled
+		err = d.jobRepository.UpdateState(job, state)
+	} else {
+		err = d.jobRepository.MarkDone(job)
+	}
+	if err != nil {
+		log.Printf("unable to record job %s as %s: %v", job.Uuid.String(), state, err)
+	}
+	d.publishProgress(finishedProgress(job, state, solution))
+}
+
+// solve turns a panicking solver into an ERROR solution so the job still
+// finishes instead of taking the server down.
+func (d *JobDispatcher) solve(ctx context.Context, job *model.Job, solver solvers.Solver) (solution *model.Solution) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("solver failed on job %s: %v", job.Uuid.String(), r)
+			solution = d.solutionFactory.ConstructEmptySolution(job, time.Since(start), model.SolutionStatusError)
+		}
+	}()
+	return solver.Solve(ctx, job)
+}
+
+// CancelJob removes a queued job from the queue or stops the solver of a
+// running one, which then stores its best solution so far as CANCELLED.
+// Either way it returns the job as it was left, waiting for a running one to
+// record its final state, which is DONE if it finished before it could stop.
+func (d *JobDispatcher) CancelJob(uuid uuid.UUID) (*model.Job, error) {
+	_, err := d.jobRepository.FindJob(uuid)
+	if err != nil {
+		return nil, err
+	}
+	d.m.Lock()
+	if running, found := d.running[uuid]; found {
+		running.cancel()
+		d.m.Unlock()
+		<-running.finished
+		return d.jobRepository.FindJob(uuid)
+	}
+	for index, queued := range d.queue {
+		if queued.job.Uuid == uuid {
+			d.queue = append(d.queue[:index], d.queue[index + 1:]...)
+			d.m.Unlock()
+			solution := d.solutionFactory.ConstructEmptySolution(queued.job, 0, model.SolutionStatusCancelled)
+			err = d.solutionRepository.InsertSolution(solution)
+			if err != nil {
+				return nil, err
+			}
+			err = d.jobRepository.UpdateState(queued.job, model.JobStateCancelled)
+			if err != nil {
+				return nil, err
+			}
+			d.publishProgress(finishedProgress(queued.job, model.JobStateCancelled, solution))
+			return d.jobRepository.FindJob(uuid)
+		}
+	}
+	d.m.Unlock()
+	return nil, fmt.Errorf("job with uuid %s is not queued or running", uuid.String())
 }
 
 func (d *JobDispatcher) FindJob(uuid uuid.UUID) (*model.Job, error) {
 	return d.jobRepository.FindJob(uuid)
 }
 
+// QueuePosition returns the 1-based position of a queued job, or nil if the
+// job is not waiting in the queue.
+func (d *JobDispatcher) QueuePosition(uuid uuid.UUID) *int {
+	d.m.Lock()
+	defer d.m.Unlock()
+	for index, queued := range d.queue {
+		if queued.job.Uuid == uuid {
+			position := index + 1
+			return &position
+		}
+	}
+	return nil
+}
+
 func (d *JobDispatcher) FindSolution(uuid uuid.UUID) (*model.Solution, error) {
 	return d.solutionRepository.FindSolution(uuid)
 }
+
+// JobSolution returns the solution of a job, or nil while it has none yet.
+func (d *JobDispatcher) JobSolution(job *model.Job) (*model.Solution, error) {
+	solution, err := d.solutionRepository.FindSolution(job.Uuid)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, nil
+	}
+	return solution, err
+}
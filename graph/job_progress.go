@@ -0,0 +1,191 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/repositories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/solvers"
+)
+
+// progressInterval is the shortest time between two progress updates of the
+// same running job, so fast solvers don't flood subscribers.
+const progressInterval = 100 * time.Millisecond
+
+// incumbentSaveInterval is the shortest time between two saves of the
+// incumbent of the same running job.
+const incumbentSaveInterval = 5 * time.Second
+
+// jobProgressReporter publishes the reports of a job's solver as JobProgress
+// updates, dropping the ones that follow the previous update too closely, and
+// saves the job's incumbent now and then so it outlives the server process.
+// The incumbent is only turned into a solution when it is published.
+type jobProgressReporter struct {
+	dispatcher *JobDispatcher
+	job *model.Job
+	start time.Time
+	last time.Time
+	lastSaved time.Time
+	best map[string]bool
+	bestCycles int
+	bestElapsed time.Duration
+	incumbent *model.Solution
+	saved *model.Solution
+}
+
+func (r *jobProgressReporter) Report(progress solvers.Progress) {
+	now := time.Now()
+	if progress.Best != nil {
+		r.best = progress.Best
+		r.bestCycles = progress.Cycles
+		r.bestElapsed = now.Sub(r.start)
+		r.incumbent = nil
+	}
+	if now.Sub(r.last) < progressInterval {
+		return
+	}
+	r.last = now
+	incumbent := r.currentIncumbent()
+	if incumbent != r.saved && now.Sub(r.lastSaved) >= incumbentSaveInterval {
+		r.lastSaved = now
+		r.saved = incumbent
+		err := r.dispatcher.solutionRepository.SaveIncumbent(incumbent)
+		if err != nil {
+			log.Printf("unable to store incumbent of job %s: %v", r.job.Uuid.String(), err)
+		}
+	}
+	r.dispatcher.publishProgress(&model.JobProgress{
+		Uuid: r.job.Uuid,
+		State: model.JobStateRunning,
+		Cycles: progress.Cycles,
+		BestScore: progress.BestScore,
+		Elapsed: now.Sub(r.start),
+		Temperature: progress.Temperature,
+		CurrentBest: incumbent,
+	})
+}
+
+func (r *jobProgressReporter) currentIncumbent() *model.Solution {
+	if r.incumbent == nil && r.best != nil {
+		r.incumbent = r.dispatcher.solutionFactory.ConstructSolution(r.best, r.job, r.bestCycles, r.bestElapsed, model.SolutionStatusUnknown)
+	}
+	return r.incumbent
+}
+
+func finishedProgress(job *model.Job, state model.JobState, solution *model.Solution) *model.JobProgress {
+	score := solution.Score
+	return &model.JobProgress{
+		Uuid: job.Uuid,
+		State: state,
+		Cycles: solution.Cycles,
+		BestScore: &score,
+		Elapsed: solution.Elapsed,
+		CurrentBest: solution,
+		Solution: solution,
+	}
+}
+
+// CurrentBest returns the best solution found so far for a job: its latest
+// incumbent while it runs, its solution once it has finished, or nil before
+// its solver has reported a complete assignment. A job picked up again after
+// a restart keeps the incumbent saved by the previous process until its
+// solver reports a new one.
+func (d *JobDispatcher) CurrentBest(job *model.Job) (*model.Solution, error) {
+	d.m.Lock()
+	progress, found := d.progress[job.Uuid]
+	d.m.Unlock()
+	if found && progress.CurrentBest != nil {
+		return progress.CurrentBest, nil
+	}
+	solution, err := d.JobSolution(job)
+	if err != nil || solution != nil {
+		return solution, err
+	}
+	incumbent, err := d.solutionRepository.FindIncumbent(job.Uuid)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, nil
+	}
+	return incumbent, err
+}
+
+// SubscribeProgress returns a channel that holds the current progress of a
+// job and then receives its updates until it finishes, when the last update
+// carries the solution and the channel is closed. A subscriber that falls
+// behind only sees the latest update. The subscription ends early once ctx
+// is done.
+func (d *JobDispatcher) SubscribeProgress(ctx context.Context, uuid uuid.UUID) (<-chan *model.JobProgress, error) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	job, err := d.jobRepository.FindJob(uuid)
+	if err != nil {
+		return nil, err
+	}
+	updates := make(chan *model.JobProgress, 1)
+	if job.State.Finished() {
+		solution, err := d.solutionRepository.FindSolution(uuid)
+		if err != nil {
+			return nil, err
+		}
+		updates <- finishedProgress(job, job.State, solution)
+		close(updates)
+		return updates, nil
+	}
+	current, found := d.progress[uuid]
+	if !found {
+		current = &model.JobProgress{Uuid: uuid, State: job.State}
+	}
+	updates <- current
+	d.subscribers[uuid] = append(d.subscribers[uuid], updates)
+	go func() {
+		<-ctx.Done()
+		d.unsubscribe(uuid, updates)
+	}()
+	return updates, nil
+}
+
+func (d *JobDispatcher) unsubscribe(uuid uuid.UUID, updates chan *model.JobProgress) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	subscribers := d.subscribers[uuid]
+	for index, subscriber := range subscribers {
+		if subscriber == updates {
+			d.subscribers[uuid] = append(subscribers[:index], subscribers[index + 1:]...)
+			close(updates)
+			break
+		}
+	}
+	if len(d.subscribers[uuid]) == 0 {
+		delete(d.subscribers, uuid)
+	}
+}
+
+// publishProgress replaces any update a subscriber has not read yet, so a
+// slow subscriber never blocks the solver. An update carrying a solution is
+// the last one for its job.
+func (d *JobDispatcher) publishProgress(progress *model.JobProgress) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	finished := progress.Solution != nil
+	if finished {
+		delete(d.progress, progress.Uuid)
+	} else {
+		d.progress[progress.Uuid] = progress
+	}
+	for _, updates := range d.subscribers[progress.Uuid] {
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- progress
+		if finished {
+			close(updates)
+		}
+	}
+	if finished {
+		delete(d.subscribers, progress.Uuid)
+	}
+}
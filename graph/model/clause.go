@@ -0,0 +1,27 @@
+package model
+
+// Clause is a disjunction of any number of literals.
+type Clause struct {
+	Literals []*Variable `json:"literals"`
+}
+
+// Var1, Var2 and Var3 serve the fields that clauses had when they were fixed
+// at three literals. They return nil past the end of the clause.
+func (c *Clause) Var1() *Variable {
+	return c.literal(0)
+}
+
+func (c *Clause) Var2() *Variable {
+	return c.literal(1)
+}
+
+func (c *Clause) Var3() *Variable {
+	return c.literal(2)
+}
+
+func (c *Clause) literal(index int) *Variable {
+	if index >= len(c.Literals) {
+		return nil
+	}
+	return c.Literals[index]
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"sort"
+)
+
+// Formula is a job's clauses compiled for solving. Variables are numbered by
+// their position in Names, which is sorted, and literals are encoded as
+// +(index+1) for a variable and -(index+1) for its negation. Duplicate
+// literals are dropped and clauses that are tautologies are only counted,
+// since every assignment satisfies them. Occurrences lists, per variable, the
+// clauses it appears in.
+type Formula struct {
+	Names []string
+	Indices map[string]int
+	Clauses [][]int
+	Occurrences [][]int
+	Tautologies int
+}
+
+func CompileFormula(clauses []*Clause) *Formula {
+	f := &Formula{
+		Names: variableNames(clauses),
+		Indices: map[string]int{},
+		Clauses: [][]int{},
+	}
+	for index, name := range f.Names {
+		f.Indices[name] = index
+	}
+	f.Occurrences = make([][]int, len(f.Names))
+	for _, clause := range clauses {
+		literals, tautology := f.compileClause(clause)
+		if tautology {
+			f.Tautologies++
+			continue
+		}
+		for _, literal := range literals {
+			variable := DecodeVariable(literal)
+			f.Occurrences[variable] = append(f.Occurrences[variable], len(f.Clauses))
+		}
+		f.Clauses = append(f.Clauses, literals)
+	}
+	return f
+}
+
+func variableNames(clauses []*Clause) []string {
+	variables := map[string]bool{}
+	for _, clause := range clauses {
+		for _, literal := range clause.Literals {
+			variables[literal.Name] = true
+		}
+	}
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f *Formula) compileClause(clause *Clause) ([]int, bool) {
+	literals := []int{}
+	for _, variable := range clause.Literals {
+		literal := f.Indices[variable.Name] + 1
+		if variable.Negated {
+			literal = -literal
+		}
+		duplicate := false
+		for _, existing := range literals {
+			if existing == -literal {
+				return nil, true
+			}
+			duplicate = duplicate || existing == literal
+		}
+		if !duplicate {
+			literals = append(literals, literal)
+		}
+	}
+	return literals, false
+}
+
+// DecodeVariable returns the index of the variable of an encoded literal.
+func DecodeVariable(literal int) int {
+	if literal < 0 {
+		return -literal - 1
+	}
+	return literal - 1
+}
+
+// ClauseCount is the number of clauses of the job, tautologies included.
+func (f *Formula) ClauseCount() int {
+	return len(f.Clauses) + f.Tautologies
+}
+
+// Satisfied counts the clauses values satisfies, tautologies included.
+func (f *Formula) Satisfied(values []bool) int {
+	count := f.Tautologies
+	for _, clause := range f.Clauses {
+		for _, literal := range clause {
+			if values[DecodeVariable(literal)] == (literal > 0) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// Score is the fraction of clauses values satisfies.
+func (f *Formula) Score(values []bool) float64 {
+	return f.ScoreSatisfied(f.Satisfied(values))
+}
+
+// ScoreSatisfied turns a count of satisfied clauses into a score, so solvers
+// that keep their own count score the same way as Score. A formula without
+// clauses is satisfied by any assignment.
+func (f *Formula) ScoreSatisfied(satisfied int) float64 {
+	if f.ClauseCount() == 0 {
+		return 1.0
+	}
+	return float64(satisfied) / float64(f.ClauseCount())
+}
+
+// Values lays out an assignment by variable index; variables missing from it
+// are false.
+func (f *Formula) Values(assignment map[string]bool) []bool {
+	values := make([]bool, len(f.Names))
+	for index, name := range f.Names {
+		values[index] = assignment[name]
+	}
+	return values
+}
+
+func (f *Formula) Assignment(values []bool) map[string]bool {
+	assignment := map[string]bool{}
+	for index, name := range f.Names {
+		assignment[name] = values[index]
+	}
+	return assignment
+}
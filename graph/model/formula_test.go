@@ -0,0 +1,83 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCompileFormula(t *testing.T) {
+	cases := []struct {
+		desc string
+		clauses []*Clause
+		wantNames []string
+		wantClauses [][]int
+		wantOccurrences [][]int
+		wantTautologies int
+	}{
+		{ "variables are numbered in sorted order", []*Clause{ clause("b", "-a"), clause("c", "a") }, []string{ "a", "b", "c" }, [][]int{ { 2, -1 }, { 3, 1 } }, [][]int{ { 0, 1 }, { 0 }, { 1 } }, 0 },
+		{ "duplicate literals are dropped", []*Clause{ clause("a", "-b", "a") }, []string{ "a", "b" }, [][]int{ { 1, -2 } }, [][]int{ { 0 }, { 0 } }, 0 },
+		{ "tautologies are only counted", []*Clause{ clause("a", "b", "-a"), clause("-b") }, []string{ "a", "b" }, [][]int{ { -2 } }, [][]int{ nil, { 0 } }, 1 },
+		{ "no clauses", []*Clause{}, []string{}, [][]int{}, [][]int{}, 0 },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// act
+			got := CompileFormula(tc.clauses)
+
+			// assert
+			if fmt.Sprint(got.Names) != fmt.Sprint(tc.wantNames) {
+				t.Errorf("wrong Names value: got %v want %v", got.Names, tc.wantNames)
+			}
+			if fmt.Sprint(got.Clauses) != fmt.Sprint(tc.wantClauses) {
+				t.Errorf("wrong Clauses value: got %v want %v", got.Clauses, tc.wantClauses)
+			}
+			if fmt.Sprint(got.Occurrences) != fmt.Sprint(tc.wantOccurrences) {
+				t.Errorf("wrong Occurrences value: got %v want %v", got.Occurrences, tc.wantOccurrences)
+			}
+			if got.Tautologies != tc.wantTautologies {
+				t.Errorf("wrong Tautologies value: got %d want %d", got.Tautologies, tc.wantTautologies)
+			}
+		})
+	}
+}
+
+func TestJobScore(t *testing.T) {
+	cases := []struct {
+		desc string
+		clauses []*Clause
+		variables map[string]bool
+		want float64
+	}{
+		{ "every clause satisfied", []*Clause{ clause("a", "b"), clause("-a") }, map[string]bool{ "a": false, "b": true }, 1.0 },
+		{ "some clauses satisfied", []*Clause{ clause("a", "b"), clause("-a"), clause("b"), clause("-b") }, map[string]bool{ "a": true, "b": false }, 0.5 },
+		{ "tautologies are satisfied", []*Clause{ clause("a", "-a"), clause("b") }, map[string]bool{}, 0.5 },
+		{ "missing variables are false", []*Clause{ clause("-a"), clause("b") }, map[string]bool{}, 0.5 },
+		{ "no clauses", []*Clause{}, map[string]bool{}, 1.0 },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := &Job{ Clauses: tc.clauses }
+
+			// act
+			got := sut.Score(tc.variables)
+
+			// assert
+			if got != tc.want {
+				t.Errorf("wrong score: got %f want %f", got, tc.want)
+			}
+		})
+	}
+}
+
+func clause(literals ...string) *Clause {
+	c := &Clause{ Literals: []*Variable{} }
+	for _, literal := range literals {
+		if literal[0] == '-' {
+			c.Literals = append(c.Literals, &Variable{ Name: literal[1:], Negated: true })
+		} else {
+			c.Literals = append(c.Literals, &Variable{ Name: literal })
+		}
+	}
+	return c
+}
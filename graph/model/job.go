@@ -1,49 +1,47 @@
 package model
 
 import (
-	"sort"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Job struct {
-	Name    string    `json:"name"`
-	Clauses []*Clause `json:"clauses"`
-	Done    bool      `json:"done"`
-	Uuid    uuid.UUID `json:"uuid"`
+	Name       string            `json:"name"`
+	Clauses    []*Clause         `json:"clauses"`
+	Done       bool              `json:"done"`
+	Uuid       uuid.UUID         `json:"uuid"`
+	Solver     SolverType        `json:"solver"`
+	Parameters *SolverParameters `json:"parameters"`
+	State      JobState          `json:"state"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	formula    atomic.Value
 }
 
-func (j *Job) Variables() []string {
-	variables := map[string]bool{}
-	for _, c := range j.Clauses {
-		variables[c.Var1.Name] = true
-		variables[c.Var2.Name] = true
-		variables[c.Var3.Name] = true
-	}
-	return j.keys(variables)
+// Finished reports whether a job in this state will no longer be solved.
+func (s JobState) Finished() bool {
+	return s == JobStateDone || s == JobStateCancelled
 }
 
-func (j *Job) keys(variables map[string]bool) []string {
-	keys := make([]string, 0, len(variables))
-	for k := range variables {
-		keys = append(keys, k)
+// Formula compiles the job's clauses the first time it is called and returns
+// the same formula afterwards, so the clauses must not change after that.
+func (j *Job) Formula() *Formula {
+	if formula, ok := j.formula.Load().(*Formula); ok {
+		return formula
 	}
-	sort.Strings(keys)
-	return keys
+	formula := CompileFormula(j.Clauses)
+	j.formula.Store(formula)
+	return formula
 }
 
-func (j *Job) Score(variables map[string]bool) float64 {
-	correct := 0
-	for _, clause := range j.Clauses {
-		if clause.satisfied(variables) {
-			correct++
-		}
-	}
-	return float64(correct) / float64(len(j.Clauses))
+// Variables returns the sorted names of the job's variables; the slice is
+// shared and must not be modified.
+func (j *Job) Variables() []string {
+	return j.Formula().Names
 }
 
-func (c *Clause) satisfied(variables map[string]bool) bool {
-	return (variables[c.Var1.Name] != c.Var1.Negated) ||
-		(variables[c.Var2.Name] != c.Var2.Negated) ||
-		(variables[c.Var3.Name] != c.Var3.Negated)
+func (j *Job) Score(variables map[string]bool) float64 {
+	formula := j.Formula()
+	return formula.Score(formula.Values(variables))
 }
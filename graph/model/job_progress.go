@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobProgress struct {
+	Uuid        uuid.UUID     `json:"uuid"`
+	State       JobState      `json:"state"`
+	Cycles      int           `json:"cycles"`
+	BestScore   *float64      `json:"bestScore"`
+	Elapsed     time.Duration `json:"elapsed"`
+	Temperature *float64      `json:"temperature"`
+	CurrentBest *Solution     `json:"currentBest"`
+	Solution    *Solution     `json:"solution"`
+}
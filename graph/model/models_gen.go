@@ -2,21 +2,83 @@
 
 package model
 
-type Clause struct {
-	Var1 *Variable `json:"var1"`
-	Var2 *Variable `json:"var2"`
-	Var3 *Variable `json:"var3"`
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type HistoryPoint struct {
+	Cycle        int      `json:"cycle"`
+	BestScore    float64  `json:"bestScore"`
+	AverageScore *float64 `json:"averageScore"`
+	Diversity    *float64 `json:"diversity"`
+	Temperature  *float64 `json:"temperature"`
+}
+
+type JobConnection struct {
+	Edges      []*JobEdge `json:"edges"`
+	PageInfo   *PageInfo  `json:"pageInfo"`
+	TotalCount int        `json:"totalCount"`
+}
+
+type JobEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Job   `json:"node"`
+}
+
+type JobFilter struct {
+	Done         *bool       `json:"done"`
+	Name         *string     `json:"name"`
+	Solver       *SolverType `json:"solver"`
+	CreatedAfter *time.Time  `json:"createdAfter"`
+}
+
+type JobOrder struct {
+	Field     JobOrderField  `json:"field"`
+	Direction OrderDirection `json:"direction"`
 }
 
 type NewClause struct {
-	Var1 *NewVariable `json:"var1"`
-	Var2 *NewVariable `json:"var2"`
-	Var3 *NewVariable `json:"var3"`
+	Literals []*NewVariable `json:"literals"`
+	Var1     *NewVariable   `json:"var1"`
+	Var2     *NewVariable   `json:"var2"`
+	Var3     *NewVariable   `json:"var3"`
 }
 
 type NewJob struct {
-	Name    string       `json:"name"`
-	Clauses []*NewClause `json:"clauses"`
+	Name       string               `json:"name"`
+	Clauses    []*NewClause         `json:"clauses"`
+	Solver     *SolverType          `json:"solver"`
+	Parameters *NewSolverParameters `json:"parameters"`
+}
+
+type NewSolverParameters struct {
+	PopulationSize        *int                 `json:"populationSize"`
+	TimeLimit             *int                 `json:"timeLimit"`
+	MutationRate          *float64             `json:"mutationRate"`
+	Seed                  *int                 `json:"seed"`
+	Noise                 *float64             `json:"noise"`
+	MaxFlips              *int                 `json:"maxFlips"`
+	MaxTries              *int                 `json:"maxTries"`
+	Selection             *SelectionOperator   `json:"selection"`
+	Crossover             *CrossoverOperator   `json:"crossover"`
+	Mutation              *MutationOperator    `json:"mutation"`
+	TournamentSize        *int                 `json:"tournamentSize"`
+	MaxGenerations        *int                 `json:"maxGenerations"`
+	Islands               *int                 `json:"islands"`
+	MigrationInterval     *int                 `json:"migrationInterval"`
+	Migrants              *int                 `json:"migrants"`
+	Threads               *int                 `json:"threads"`
+	RefinementFlips       *int                 `json:"refinementFlips"`
+	RefinementProbability *float64             `json:"refinementProbability"`
+	CoolingSchedule       *CoolingSchedule     `json:"coolingSchedule"`
+	InitialTemperature    *float64             `json:"initialTemperature"`
+	FinalTemperature      *float64             `json:"finalTemperature"`
+	TabuTenure            *int                 `json:"tabuTenure"`
+	Aspiration            *AspirationCriterion `json:"aspiration"`
+	Portfolio             []SolverType         `json:"portfolio"`
 }
 
 type NewVariable struct {
@@ -24,12 +86,494 @@ type NewVariable struct {
 	Name    string `json:"name"`
 }
 
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
 type SolvedVariable struct {
 	Name  string `json:"name"`
 	Value bool   `json:"value"`
 }
 
+type SolverParameters struct {
+	PopulationSize        *int                 `json:"populationSize"`
+	TimeLimit             *int                 `json:"timeLimit"`
+	MutationRate          *float64             `json:"mutationRate"`
+	Seed                  *int                 `json:"seed"`
+	Noise                 *float64             `json:"noise"`
+	MaxFlips              *int                 `json:"maxFlips"`
+	MaxTries              *int                 `json:"maxTries"`
+	Selection             *SelectionOperator   `json:"selection"`
+	Crossover             *CrossoverOperator   `json:"crossover"`
+	Mutation              *MutationOperator    `json:"mutation"`
+	TournamentSize        *int                 `json:"tournamentSize"`
+	MaxGenerations        *int                 `json:"maxGenerations"`
+	Islands               *int                 `json:"islands"`
+	MigrationInterval     *int                 `json:"migrationInterval"`
+	Migrants              *int                 `json:"migrants"`
+	Threads               *int                 `json:"threads"`
+	RefinementFlips       *int                 `json:"refinementFlips"`
+	RefinementProbability *float64             `json:"refinementProbability"`
+	CoolingSchedule       *CoolingSchedule     `json:"coolingSchedule"`
+	InitialTemperature    *float64             `json:"initialTemperature"`
+	FinalTemperature      *float64             `json:"finalTemperature"`
+	TabuTenure            *int                 `json:"tabuTenure"`
+	Aspiration            *AspirationCriterion `json:"aspiration"`
+	Portfolio             []SolverType         `json:"portfolio"`
+}
+
 type Variable struct {
 	Negated bool   `json:"negated"`
 	Name    string `json:"name"`
 }
+
+type AspirationCriterion string
+
+const (
+	AspirationCriterionBest      AspirationCriterion = "BEST"
+	AspirationCriterionImproving AspirationCriterion = "IMPROVING"
+	AspirationCriterionNone      AspirationCriterion = "NONE"
+)
+
+var AllAspirationCriterion = []AspirationCriterion{
+	AspirationCriterionBest,
+	AspirationCriterionImproving,
+	AspirationCriterionNone,
+}
+
+func (e AspirationCriterion) IsValid() bool {
+	switch e {
+	case AspirationCriterionBest, AspirationCriterionImproving, AspirationCriterionNone:
+		return true
+	}
+	return false
+}
+
+func (e AspirationCriterion) String() string {
+	return string(e)
+}
+
+func (e *AspirationCriterion) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AspirationCriterion(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AspirationCriterion", str)
+	}
+	return nil
+}
+
+func (e AspirationCriterion) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CoolingSchedule string
+
+const (
+	CoolingScheduleGeometric      CoolingSchedule = "GEOMETRIC"
+	CoolingScheduleLinear         CoolingSchedule = "LINEAR"
+	CoolingScheduleAdaptiveReheat CoolingSchedule = "ADAPTIVE_REHEAT"
+)
+
+var AllCoolingSchedule = []CoolingSchedule{
+	CoolingScheduleGeometric,
+	CoolingScheduleLinear,
+	CoolingScheduleAdaptiveReheat,
+}
+
+func (e CoolingSchedule) IsValid() bool {
+	switch e {
+	case CoolingScheduleGeometric, CoolingScheduleLinear, CoolingScheduleAdaptiveReheat:
+		return true
+	}
+	return false
+}
+
+func (e CoolingSchedule) String() string {
+	return string(e)
+}
+
+func (e *CoolingSchedule) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CoolingSchedule(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CoolingSchedule", str)
+	}
+	return nil
+}
+
+func (e CoolingSchedule) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CrossoverOperator string
+
+const (
+	CrossoverOperatorUniform  CrossoverOperator = "UNIFORM"
+	CrossoverOperatorOnePoint CrossoverOperator = "ONE_POINT"
+	CrossoverOperatorTwoPoint CrossoverOperator = "TWO_POINT"
+)
+
+var AllCrossoverOperator = []CrossoverOperator{
+	CrossoverOperatorUniform,
+	CrossoverOperatorOnePoint,
+	CrossoverOperatorTwoPoint,
+}
+
+func (e CrossoverOperator) IsValid() bool {
+	switch e {
+	case CrossoverOperatorUniform, CrossoverOperatorOnePoint, CrossoverOperatorTwoPoint:
+		return true
+	}
+	return false
+}
+
+func (e CrossoverOperator) String() string {
+	return string(e)
+}
+
+func (e *CrossoverOperator) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CrossoverOperator(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CrossoverOperator", str)
+	}
+	return nil
+}
+
+func (e CrossoverOperator) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type JobOrderField string
+
+const (
+	JobOrderFieldCreatedAt JobOrderField = "CREATED_AT"
+	JobOrderFieldName      JobOrderField = "NAME"
+)
+
+var AllJobOrderField = []JobOrderField{
+	JobOrderFieldCreatedAt,
+	JobOrderFieldName,
+}
+
+func (e JobOrderField) IsValid() bool {
+	switch e {
+	case JobOrderFieldCreatedAt, JobOrderFieldName:
+		return true
+	}
+	return false
+}
+
+func (e JobOrderField) String() string {
+	return string(e)
+}
+
+func (e *JobOrderField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = JobOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid JobOrderField", str)
+	}
+	return nil
+}
+
+func (e JobOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type JobState string
+
+const (
+	JobStateQueued    JobState = "QUEUED"
+	JobStateRunning   JobState = "RUNNING"
+	JobStateDone      JobState = "DONE"
+	JobStateCancelled JobState = "CANCELLED"
+)
+
+var AllJobState = []JobState{
+	JobStateQueued,
+	JobStateRunning,
+	JobStateDone,
+	JobStateCancelled,
+}
+
+func (e JobState) IsValid() bool {
+	switch e {
+	case JobStateQueued, JobStateRunning, JobStateDone, JobStateCancelled:
+		return true
+	}
+	return false
+}
+
+func (e JobState) String() string {
+	return string(e)
+}
+
+func (e *JobState) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = JobState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid JobState", str)
+	}
+	return nil
+}
+
+func (e JobState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MutationOperator string
+
+const (
+	MutationOperatorFixed    MutationOperator = "FIXED"
+	MutationOperatorAdaptive MutationOperator = "ADAPTIVE"
+	MutationOperatorFocused  MutationOperator = "FOCUSED"
+)
+
+var AllMutationOperator = []MutationOperator{
+	MutationOperatorFixed,
+	MutationOperatorAdaptive,
+	MutationOperatorFocused,
+}
+
+func (e MutationOperator) IsValid() bool {
+	switch e {
+	case MutationOperatorFixed, MutationOperatorAdaptive, MutationOperatorFocused:
+		return true
+	}
+	return false
+}
+
+func (e MutationOperator) String() string {
+	return string(e)
+}
+
+func (e *MutationOperator) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MutationOperator(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MutationOperator", str)
+	}
+	return nil
+}
+
+func (e MutationOperator) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "ASC"
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SelectionOperator string
+
+const (
+	SelectionOperatorRoulette   SelectionOperator = "ROULETTE"
+	SelectionOperatorRank       SelectionOperator = "RANK"
+	SelectionOperatorTournament SelectionOperator = "TOURNAMENT"
+)
+
+var AllSelectionOperator = []SelectionOperator{
+	SelectionOperatorRoulette,
+	SelectionOperatorRank,
+	SelectionOperatorTournament,
+}
+
+func (e SelectionOperator) IsValid() bool {
+	switch e {
+	case SelectionOperatorRoulette, SelectionOperatorRank, SelectionOperatorTournament:
+		return true
+	}
+	return false
+}
+
+func (e SelectionOperator) String() string {
+	return string(e)
+}
+
+func (e *SelectionOperator) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SelectionOperator(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SelectionOperator", str)
+	}
+	return nil
+}
+
+func (e SelectionOperator) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SolutionStatus string
+
+const (
+	SolutionStatusSatisfiable   SolutionStatus = "SATISFIABLE"
+	SolutionStatusUnsatisfiable SolutionStatus = "UNSATISFIABLE"
+	SolutionStatusUnknown       SolutionStatus = "UNKNOWN"
+	SolutionStatusTimeout       SolutionStatus = "TIMEOUT"
+	SolutionStatusCancelled     SolutionStatus = "CANCELLED"
+	SolutionStatusError         SolutionStatus = "ERROR"
+)
+
+var AllSolutionStatus = []SolutionStatus{
+	SolutionStatusSatisfiable,
+	SolutionStatusUnsatisfiable,
+	SolutionStatusUnknown,
+	SolutionStatusTimeout,
+	SolutionStatusCancelled,
+	SolutionStatusError,
+}
+
+func (e SolutionStatus) IsValid() bool {
+	switch e {
+	case SolutionStatusSatisfiable, SolutionStatusUnsatisfiable, SolutionStatusUnknown, SolutionStatusTimeout, SolutionStatusCancelled, SolutionStatusError:
+		return true
+	}
+	return false
+}
+
+func (e SolutionStatus) String() string {
+	return string(e)
+}
+
+func (e *SolutionStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SolutionStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SolutionStatus", str)
+	}
+	return nil
+}
+
+func (e SolutionStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SolverType string
+
+const (
+	SolverTypeGenetic            SolverType = "GENETIC"
+	SolverTypeNaive              SolverType = "NAIVE"
+	SolverTypeDpll               SolverType = "DPLL"
+	SolverTypeCdcl               SolverType = "CDCL"
+	SolverTypeWalksat            SolverType = "WALKSAT"
+	SolverTypeGsat               SolverType = "GSAT"
+	SolverTypeIsland             SolverType = "ISLAND"
+	SolverTypeSimulatedAnnealing SolverType = "SIMULATED_ANNEALING"
+	SolverTypeTabu               SolverType = "TABU"
+	SolverTypePortfolio          SolverType = "PORTFOLIO"
+)
+
+var AllSolverType = []SolverType{
+	SolverTypeGenetic,
+	SolverTypeNaive,
+	SolverTypeDpll,
+	SolverTypeCdcl,
+	SolverTypeWalksat,
+	SolverTypeGsat,
+	SolverTypeIsland,
+	SolverTypeSimulatedAnnealing,
+	SolverTypeTabu,
+	SolverTypePortfolio,
+}
+
+func (e SolverType) IsValid() bool {
+	switch e {
+	case SolverTypeGenetic, SolverTypeNaive, SolverTypeDpll, SolverTypeCdcl, SolverTypeWalksat, SolverTypeGsat, SolverTypeIsland, SolverTypeSimulatedAnnealing, SolverTypeTabu, SolverTypePortfolio:
+		return true
+	}
+	return false
+}
+
+func (e SolverType) String() string {
+	return string(e)
+}
+
+func (e *SolverType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SolverType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SolverType", str)
+	}
+	return nil
+}
+
+func (e SolverType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -12,4 +12,7 @@ type Solution struct {
 	Score     float64           `json:"score"`
 	Cycles    int               `json:"cycles"`
 	Elapsed   time.Duration     `json:"elapsed"`
+	Status    SolutionStatus    `json:"status"`
+	SolvedBy  *SolverType       `json:"solvedBy"`
+	History   []*HistoryPoint   `json:"history"`
 }
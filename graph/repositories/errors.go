@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	u "github.com/google/uuid"
+)
+
+// ErrNotFound matches, through errors.Is, the error a repository returns when
+// nothing is stored under a uuid.
+var ErrNotFound = errors.New("not found")
+
+type notFoundError struct {
+	kind string
+	uuid u.UUID
+}
+
+func newNotFoundError(kind string, uuid u.UUID) error {
+	return &notFoundError{kind: kind, uuid: uuid}
+}
+
+func (e *notFoundError) Error() string {
+	return fmt.Sprintf("unable to find %s with uuid %s", e.kind, e.uuid.String())
+}
+
+func (e *notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
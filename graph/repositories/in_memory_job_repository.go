@@ -1,7 +1,8 @@
 package repositories
 
 import (
-	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	u "github.com/google/uuid"
@@ -15,26 +16,147 @@ type InMemoryJobRepository struct {
 
 func (r* InMemoryJobRepository) FindJob(uuid u.UUID) (*model.Job, error) {
 	r.m.RLock()
+	defer r.m.RUnlock()
 	for _, j := range r.jobs {
 		if j.Uuid == uuid {
-			r.m.RUnlock()
-			return j, nil
+			return copyJob(j), nil
+		}
+	}
+	return nil, newNotFoundError("job", uuid)
+}
+
+func (r* InMemoryJobRepository) FindJobsByState(state model.JobState) ([]*model.Job, error) {
+	r.m.RLock()
+	jobs := []*model.Job{}
+	for _, j := range r.jobs {
+		if j.State == state {
+			jobs = append(jobs, copyJob(j))
 		}
 	}
 	r.m.RUnlock()
-	return nil, fmt.Errorf("unable to find job with uuid %s", uuid.String())
+	return jobs, nil
+}
+
+func (r* InMemoryJobRepository) FindJobs(filter *model.JobFilter, order *model.JobOrder, after *u.UUID, limit int) ([]*model.Job, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	order = jobOrder(order)
+	positions := map[*model.Job]int{}
+	matching := []*model.Job{}
+	var afterJob *model.Job
+	for index, j := range r.jobs {
+		positions[j] = index
+		if matchesFilter(j, filter) {
+			matching = append(matching, j)
+		}
+		if after != nil && j.Uuid == *after {
+			afterJob = j
+		}
+	}
+	if after != nil && afterJob == nil {
+		return nil, newNotFoundError("job", *after)
+	}
+	before := func(a *model.Job, b *model.Job) bool {
+		return jobBefore(a, b, order, positions)
+	}
+	sort.Slice(matching, func(i int, j int) bool {
+		return before(matching[i], matching[j])
+	})
+	start := 0
+	if afterJob != nil {
+		start = sort.Search(len(matching), func(i int) bool {
+			return before(afterJob, matching[i])
+		})
+	}
+	end := start + limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+	jobs := []*model.Job{}
+	for _, j := range matching[start:end] {
+		jobs = append(jobs, copyJob(j))
+	}
+	return jobs, nil
+}
+
+func (r* InMemoryJobRepository) CountJobs(filter *model.JobFilter) (int, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	count := 0
+	for _, j := range r.jobs {
+		if matchesFilter(j, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func matchesFilter(job *model.Job, filter *model.JobFilter) bool {
+	if filter == nil {
+		return true
+	}
+	return (filter.Done == nil || job.Done == *filter.Done) &&
+		(filter.Name == nil || strings.Contains(job.Name, *filter.Name)) &&
+		(filter.Solver == nil || job.Solver == *filter.Solver) &&
+		(filter.CreatedAfter == nil || job.CreatedAt.After(*filter.CreatedAfter))
+}
+
+// jobBefore orders jobs by the requested field and then by insertion order,
+// both in the requested direction, the same way SqliteJobRepository does.
+func jobBefore(a *model.Job, b *model.Job, order *model.JobOrder, positions map[*model.Job]int) bool {
+	if order.Direction == model.OrderDirectionDesc {
+		a, b = b, a
+	}
+	switch order.Field {
+	case model.JobOrderFieldName:
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+	default:
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+	}
+	return positions[a] < positions[b]
 }
 
 func (r* InMemoryJobRepository) InsertJob(job *model.Job) error {
 	r.m.Lock()
-	r.jobs = append(r.jobs, job)
+	r.jobs = append(r.jobs, copyJob(job))
 	r.m.Unlock()
 	return nil
 }
 
 func (r* InMemoryJobRepository) MarkDone(job *model.Job) error {
+	return r.UpdateState(job, model.JobStateDone)
+}
+
+// UpdateState changes the stored job rather than the one passed in, the same
+// way SqliteJobRepository does, since other goroutines may be reading it.
+func (r* InMemoryJobRepository) UpdateState(job *model.Job, state model.JobState) error {
 	r.m.Lock()
-	job.Done = true
-	r.m.Unlock()
-	return nil
+	defer r.m.Unlock()
+	for _, j := range r.jobs {
+		if j.Uuid == job.Uuid {
+			j.State = state
+			j.Done = state.Finished()
+			return nil
+		}
+	}
+	return newNotFoundError("job", job.Uuid)
+}
+
+// copyJob copies the fields a caller may read, so that the jobs handed out
+// never change under them. The copy compiles its own formula when asked.
+func copyJob(job *model.Job) *model.Job {
+	return &model.Job{
+		Name: job.Name,
+		Clauses: job.Clauses,
+		Done: job.Done,
+		Uuid: job.Uuid,
+		Solver: job.Solver,
+		Parameters: job.Parameters,
+		State: job.State,
+		CreatedAt: job.CreatedAt,
+	}
 }
@@ -1,7 +1,6 @@
 package repositories
 
 import (
-	"fmt"
 	"sync"
 
 	u "github.com/google/uuid"
@@ -10,6 +9,7 @@ import (
 
 type InMemorySolutionRepository struct {
 	solutions []*model.Solution
+	incumbents map[u.UUID]*model.Solution
 	m sync.RWMutex
 }
 
@@ -22,11 +22,38 @@ func (r* InMemorySolutionRepository) FindSolution(uuid u.UUID) (*model.Solution,
 		}
 	}
 	r.m.RUnlock()
-	return nil, fmt.Errorf("unable to find solution with uuid %s", uuid.String())
+	return nil, newNotFoundError("solution", uuid)
 }
 
-func (r* InMemorySolutionRepository) InsertSolution(solutions *model.Solution) {
+func (r* InMemorySolutionRepository) InsertSolution(solutions *model.Solution) error {
 	r.m.Lock()
+	for index, solution := range r.solutions {
+		if solution.Uuid == solutions.Uuid {
+			r.solutions = append(r.solutions[:index], r.solutions[index + 1:]...)
+			break
+		}
+	}
 	r.solutions = append(r.solutions, solutions)
+	delete(r.incumbents, solutions.Uuid)
+	r.m.Unlock()
+	return nil
+}
+
+func (r* InMemorySolutionRepository) FindIncumbent(uuid u.UUID) (*model.Solution, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	if incumbent, found := r.incumbents[uuid]; found {
+		return incumbent, nil
+	}
+	return nil, newNotFoundError("incumbent", uuid)
+}
+
+func (r* InMemorySolutionRepository) SaveIncumbent(solution *model.Solution) error {
+	r.m.Lock()
+	if r.incumbents == nil {
+		r.incumbents = map[u.UUID]*model.Solution{}
+	}
+	r.incumbents[solution.Uuid] = solution
 	r.m.Unlock()
+	return nil
 }
@@ -7,6 +7,20 @@ import (
 
 type JobRepository interface {
 	FindJob(uuid u.UUID) (*model.Job, error)
+	FindJobsByState(state model.JobState) ([]*model.Job, error)
+	// FindJobs returns up to limit jobs that match filter, sorted by order and
+	// starting after the job with uuid after unless it is nil. A nil filter
+	// matches every job and a nil order sorts by creation time, oldest first.
+	FindJobs(filter *model.JobFilter, order *model.JobOrder, after *u.UUID, limit int) ([]*model.Job, error)
+	CountJobs(filter *model.JobFilter) (int, error)
 	InsertJob(job *model.Job) error
 	MarkDone(job *model.Job) error
+	UpdateState(job *model.Job, state model.JobState) error
+}
+
+func jobOrder(order *model.JobOrder) *model.JobOrder {
+	if order == nil {
+		return &model.JobOrder{Field: model.JobOrderFieldCreatedAt, Direction: model.OrderDirectionAsc}
+	}
+	return order
 }
@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	u "github.com/google/uuid"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestFindJobs(t *testing.T) {
+	base := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	jobs := []*model.Job{
+		listedJob("alpha", base, model.SolverTypeGenetic, true),
+		listedJob("beta", base.Add(1 * time.Second), model.SolverTypeCdcl, false),
+		listedJob("gamma alpha", base.Add(2 * time.Second), model.SolverTypeCdcl, true),
+		listedJob("delta", base.Add(3 * time.Second), model.SolverTypeDpll, false),
+		listedJob("beta", base.Add(4 * time.Second), model.SolverTypeGenetic, false),
+	}
+	done := true
+	name := "alpha"
+	solver := model.SolverTypeCdcl
+	createdAfter := base.Add(2 * time.Second)
+	byName := &model.JobOrder{Field: model.JobOrderFieldName, Direction: model.OrderDirectionAsc}
+	byNameDesc := &model.JobOrder{Field: model.JobOrderFieldName, Direction: model.OrderDirectionDesc}
+	newest := &model.JobOrder{Field: model.JobOrderFieldCreatedAt, Direction: model.OrderDirectionDesc}
+	cases := []struct {
+		desc string
+		filter *model.JobFilter
+		order *model.JobOrder
+		after *model.Job
+		limit int
+		want []*model.Job
+		wantCount int
+	}{
+		{ "oldest first by default", nil, nil, nil, 10, jobs, 5 },
+		{ "limit cuts the page", nil, nil, nil, 2, jobs[:2], 5 },
+		{ "page starts after the cursor", nil, nil, jobs[1], 2, jobs[2:4], 5 },
+		{ "newest first", nil, newest, jobs[3], 10, []*model.Job{ jobs[2], jobs[1], jobs[0] }, 5 },
+		{ "by name with ties in insertion order", nil, byName, nil, 10, []*model.Job{ jobs[0], jobs[1], jobs[4], jobs[3], jobs[2] }, 5 },
+		{ "by name after a tied cursor", nil, byName, jobs[1], 2, []*model.Job{ jobs[4], jobs[3] }, 5 },
+		{ "by name descending", nil, byNameDesc, jobs[4], 10, []*model.Job{ jobs[1], jobs[0] }, 5 },
+		{ "filter on done", &model.JobFilter{Done: &done}, nil, nil, 10, []*model.Job{ jobs[0], jobs[2] }, 2 },
+		{ "filter on part of the name", &model.JobFilter{Name: &name}, nil, nil, 10, []*model.Job{ jobs[0], jobs[2] }, 2 },
+		{ "filter on solver", &model.JobFilter{Solver: &solver}, nil, nil, 10, []*model.Job{ jobs[1], jobs[2] }, 2 },
+		{ "filter on creation time", &model.JobFilter{CreatedAfter: &createdAfter}, nil, nil, 10, jobs[3:], 2 },
+		{ "cursor outside the filter", &model.JobFilter{Done: &done}, nil, jobs[1], 10, []*model.Job{ jobs[2] }, 2 },
+	}
+	for _, repository := range listingRepositories(t) {
+		for _, job := range jobs {
+			err := repository.sut.InsertJob(job)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, tc := range cases {
+			t.Run(fmt.Sprintf("%s %s", repository.desc, tc.desc), func(t *testing.T) {
+				// arrange
+				var after *u.UUID
+				if tc.after != nil {
+					after = &tc.after.Uuid
+				}
+
+				// act
+				got, err := repository.sut.FindJobs(tc.filter, tc.order, after, tc.limit)
+				count, countErr := repository.sut.CountJobs(tc.filter)
+
+				// assert
+				if err != nil {
+					t.Fatalf("failed to find jobs: %v", err)
+				}
+				if countErr != nil {
+					t.Fatalf("failed to count jobs: %v", countErr)
+				}
+				if len(got) != len(tc.want) {
+					t.Fatalf("wrong number of jobs: got %d want %d", len(got), len(tc.want))
+				}
+				for index, want := range tc.want {
+					verifyJobsAreEqual(t, got[index], want)
+					if !got[index].CreatedAt.Equal(want.CreatedAt) {
+						t.Errorf("wrong CreatedAt for job %d: got %s want %s", index, got[index].CreatedAt, want.CreatedAt)
+					}
+				}
+				if count != tc.wantCount {
+					t.Errorf("wrong count: got %d want %d", count, tc.wantCount)
+				}
+			})
+		}
+	}
+}
+
+func TestFindJobsWhenCursorIsUnknown(t *testing.T) {
+	for _, repository := range listingRepositories(t) {
+		t.Run(repository.desc, func(t *testing.T) {
+			// arrange
+			after := u.New()
+
+			// act
+			_, err := repository.sut.FindJobs(nil, nil, &after, 10)
+
+			// assert
+			want := "unable to find job with uuid " + after.String()
+			if err == nil || err.Error() != want {
+				t.Errorf("wrong error: got '%v' want '%s'", err, want)
+			}
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("error does not match ErrNotFound: %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateStateLeavesFoundJobsAlone(t *testing.T) {
+	for _, repository := range listingRepositories(t) {
+		t.Run(repository.desc, func(t *testing.T) {
+			// arrange
+			job := listedJob("job", time.Now(), model.SolverTypeNaive, false)
+			err := repository.sut.InsertJob(job)
+			if err != nil {
+				t.Fatalf("failed to insert job: %v", err)
+			}
+			found, err := repository.sut.FindJob(job.Uuid)
+			if err != nil {
+				t.Fatalf("failed to find job: %v", err)
+			}
+
+			// act
+			err = repository.sut.UpdateState(found, model.JobStateRunning)
+
+			// assert
+			if err != nil {
+				t.Fatalf("failed to update state: %v", err)
+			}
+			if found.State != model.JobStateQueued || job.State != model.JobStateQueued {
+				t.Errorf("changed a job held by the caller: got (%s %s) want (%s %s)", found.State, job.State, model.JobStateQueued, model.JobStateQueued)
+			}
+			got, err := repository.sut.FindJob(job.Uuid)
+			if err != nil {
+				t.Fatalf("failed to find job: %v", err)
+			}
+			if got.State != model.JobStateRunning {
+				t.Errorf("wrong state: got '%s' want '%s'", got.State, model.JobStateRunning)
+			}
+		})
+	}
+}
+
+type listingRepository struct {
+	desc string
+	sut JobRepository
+}
+
+func listingRepositories(t testing.TB) []listingRepository {
+	listingDbName := "testJobListing.db"
+	os.Remove(listingDbName)
+	t.Cleanup(func() {
+		os.Remove(listingDbName)
+	})
+	return []listingRepository{
+		{ "sqlite", NewSqliteJobRepository(listingDbName) },
+		{ "in memory", &InMemoryJobRepository{} },
+	}
+}
+
+func listedJob(name string, createdAt time.Time, solver model.SolverType, done bool) *model.Job {
+	state := model.JobStateQueued
+	if done {
+		state = model.JobStateDone
+	}
+	return jobWithOneClause(u.New(), func(j *model.Job) {
+		j.Name = name
+		j.CreatedAt = createdAt
+		j.Solver = solver
+		j.Done = done
+		j.State = state
+	})
+}
@@ -5,7 +5,13 @@ import (
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
 )
 
+// An incumbent is the best solution found so far for a job that is still
+// running. SaveIncumbent replaces any earlier one and InsertSolution drops it,
+// since the final solution supersedes it. InsertSolution also replaces any
+// earlier solution of the job, which a job re-run after a crash may have.
 type SolutionRepository interface {
 	FindSolution(uuid u.UUID) (*model.Solution, error)
-	InsertSolution(solution *model.Solution)
+	InsertSolution(solution *model.Solution) error
+	FindIncumbent(uuid u.UUID) (*model.Solution, error)
+	SaveIncumbent(solution *model.Solution) error
 }
@@ -2,7 +2,10 @@ package repositories
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	u "github.com/google/uuid"
 	_ "github.com/mattn/go-sqlite3"
@@ -31,6 +34,161 @@ func (r* SqliteJobRepository) FindJob(uuid u.UUID) (*model.Job, error) {
 	return job, nil
 }
 
+// FindJobsByState returns the jobs in the given state in the order they were
+// inserted. Rows written before the state column existed count as QUEUED or
+// DONE depending on their done flag, just like in FindJob.
+func (r* SqliteJobRepository) FindJobsByState(state model.JobState) ([]*model.Job, error) {
+	uuidRows, err := r.db.Query("SELECT uuid FROM jobs WHERE COALESCE(state, CASE WHEN done THEN ? ELSE ? END) = ? ORDER BY id",
+		model.JobStateDone.String(), model.JobStateQueued.String(), state.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs by state: %v", err)
+	}
+	uuids := []u.UUID{}
+	for uuidRows.Next() {
+		var uuid u.UUID
+		uuidRows.Scan(&uuid)
+		uuids = append(uuids, uuid)
+	}
+	uuidRows.Close()
+	jobs := []*model.Job{}
+	for _, uuid := range uuids {
+		job, err := r.FindJob(uuid)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (r* SqliteJobRepository) FindJobs(filter *model.JobFilter, order *model.JobOrder, after *u.UUID, limit int) ([]*model.Job, error) {
+	where, args := jobFilterClause(filter)
+	key, direction, comparison := jobOrderClause(jobOrder(order))
+	if after != nil {
+		afterRow := r.db.QueryRow("SELECT " + key + ", id FROM jobs WHERE uuid = ?", after.String())
+		var afterKey interface{}
+		var afterId int64
+		err := afterRow.Scan(&afterKey, &afterId)
+		if err == sql.ErrNoRows {
+			return nil, newNotFoundError("job", *after)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query cursor job: %v", err)
+		}
+		where += fmt.Sprintf(" AND (%s, id) %s (?, ?)", key, comparison)
+		args = append(args, afterKey, afterId)
+	}
+	args = append(args, limit)
+	jobRows, err := r.db.Query(fmt.Sprintf("SELECT %s FROM jobs WHERE %s ORDER BY %s %s, id %s LIMIT ?", jobColumns, where, key, direction, direction), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs: %v", err)
+	}
+	jobs := []*model.Job{}
+	for jobRows.Next() {
+		job, err := scanJob(jobRows)
+		if err != nil {
+			jobRows.Close()
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	jobRows.Close()
+	err = r.attachClauses(jobs)
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r* SqliteJobRepository) CountJobs(filter *model.JobFilter) (int, error) {
+	where, args := jobFilterClause(filter)
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM jobs WHERE " + where, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count jobs: %v", err)
+	}
+	return count, nil
+}
+
+func jobFilterClause(filter *model.JobFilter) (string, []interface{}) {
+	conditions := []string{"1 = 1"}
+	args := []interface{}{}
+	if filter == nil {
+		return conditions[0], args
+	}
+	if filter.Done != nil {
+		conditions = append(conditions, "done = ?")
+		args = append(args, *filter.Done)
+	}
+	if filter.Name != nil {
+		conditions = append(conditions, "instr(name, ?) > 0")
+		args = append(args, *filter.Name)
+	}
+	if filter.Solver != nil {
+		conditions = append(conditions, "COALESCE(solver, ?) = ?")
+		args = append(args, model.SolverTypeGenetic.String(), filter.Solver.String())
+	}
+	if filter.CreatedAfter != nil {
+		conditions = append(conditions, "created_at > ?")
+		args = append(args, filter.CreatedAfter.UnixNano())
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// jobOrderClause returns the sort key, the direction and the comparison that
+// selects the rows after a cursor. Ties are broken by id in the same direction.
+func jobOrderClause(order *model.JobOrder) (string, string, string) {
+	key := "created_at"
+	if order.Field == model.JobOrderFieldName {
+		key = "name"
+	}
+	if order.Direction == model.OrderDirectionDesc {
+		return key, "DESC", "<"
+	}
+	return key, "ASC", ">"
+}
+
+// attachClauses loads the clauses of a page of jobs with a single query.
+func (r* SqliteJobRepository) attachClauses(jobs []*model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	byUuid := map[string]*model.Job{}
+	placeholders := []string{}
+	args := []interface{}{}
+	for _, job := range jobs {
+		job.Clauses = []*model.Clause{}
+		byUuid[job.Uuid.String()] = job
+		placeholders = append(placeholders, "?")
+		args = append(args, job.Uuid.String())
+	}
+	literalRows, err := r.db.Query("SELECT uuid, clause, name, negated FROM literals WHERE uuid IN (" + strings.Join(placeholders, ", ") + ") ORDER BY uuid, clause, position", args...)
+	if err != nil {
+		return fmt.Errorf("failed to query literals: %v", err)
+	}
+	for literalRows.Next() {
+		var uuid string
+		var index int
+		literal := &model.Variable{}
+		literalRows.Scan(&uuid, &index, &literal.Name, &literal.Negated)
+		job := byUuid[uuid]
+		for len(job.Clauses) <= index {
+			job.Clauses = append(job.Clauses, &model.Clause{Literals: []*model.Variable{}})
+		}
+		job.Clauses[index].Literals = append(job.Clauses[index].Literals, literal)
+	}
+	literalRows.Close()
+	for _, job := range jobs {
+		if len(job.Clauses) == 0 {
+			job.Clauses, err = r.queryLegacyClauses(job.Uuid)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (r* SqliteJobRepository) InsertJob(job *model.Job) error {
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -40,7 +198,7 @@ func (r* SqliteJobRepository) InsertJob(job *model.Job) error {
 	if err != nil {
 		return err
 	}
-	err = r.insertClauseRows(job, tx)
+	err = r.insertLiteralRows(job, tx)
 	if err != nil {
 		return err
 	}
@@ -53,62 +211,135 @@ func (r* SqliteJobRepository) MarkDone(job *model.Job) error {
 	if err != nil {
 		return fmt.Errorf("unable to create mark done transaction: %v", err)
 	}
-	statement, err := tx.Prepare("UPDATE jobs SET done = ? WHERE uuid = ?")
+	statement, err := tx.Prepare("UPDATE jobs SET done = ?, state = ? WHERE uuid = ?")
 	if err != nil {
 		return err
 	}
 	defer statement.Close()
-	_, err = statement.Exec(true, job.Uuid.String())
+	_, err = statement.Exec(true, model.JobStateDone.String(), job.Uuid.String())
+	tx.Commit()
+	return err
+}
+
+func (r* SqliteJobRepository) UpdateState(job *model.Job, state model.JobState) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to create update state transaction: %v", err)
+	}
+	statement, err := tx.Prepare("UPDATE jobs SET state = ?, done = ? WHERE uuid = ?")
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+	_, err = statement.Exec(state.String(), state.Finished(), job.Uuid.String())
 	tx.Commit()
 	return err
 }
 
 func (r* SqliteJobRepository) insertJobRow(job *model.Job, tx *sql.Tx) error {
-	statement, err := tx.Prepare("INSERT INTO jobs (uuid, done, name) VALUES (?, ?, ?)")
+	parameters, err := json.Marshal(job.Parameters)
+	if err != nil {
+		return fmt.Errorf("failed to encode solver parameters: %v", err)
+	}
+	statement, err := tx.Prepare("INSERT INTO jobs (uuid, done, name, solver, parameters, state, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		return fmt.Errorf("failed to create insert job statement: %v", err)
 	}
 	defer statement.Close()
-	_, err = statement.Exec(job.Uuid, job.Done, job.Name)
+	_, err = statement.Exec(job.Uuid, job.Done, job.Name, job.Solver.String(), string(parameters), job.State.String(), job.CreatedAt.UnixNano())
 	if err != nil {
 		return fmt.Errorf("failed to execute insert job statement: %v", err)
 	}
 	return err
 }
 
-func (r* SqliteJobRepository) insertClauseRows(job *model.Job, tx *sql.Tx) error {
-	statement, err := tx.Prepare("INSERT INTO clauses (uuid, var1, var1negated, var2, var2negated, var3, var3negated) VALUES (?, ?, ?, ?, ?, ?, ?)")
+func (r* SqliteJobRepository) insertLiteralRows(job *model.Job, tx *sql.Tx) error {
+	statement, err := tx.Prepare("INSERT INTO literals (uuid, clause, position, name, negated) VALUES (?, ?, ?, ?, ?)")
 	if err != nil {
-		return fmt.Errorf("failed to create insert clause statement: %v", err)
+		return fmt.Errorf("failed to create insert literal statement: %v", err)
 	}
 	defer statement.Close()
-	for _, clause := range job.Clauses {
-		_, err = statement.Exec(job.Uuid.String(), clause.Var1.Name, clause.Var1.Negated, clause.Var2.Name, clause.Var2.Negated, clause.Var3.Name, clause.Var3.Negated)
-		if err != nil {
-			return fmt.Errorf("failed to execute insert clause statement: %v", err)
+	for index, clause := range job.Clauses {
+		for position, literal := range clause.Literals {
+			_, err = statement.Exec(job.Uuid.String(), index, position, literal.Name, literal.Negated)
+			if err != nil {
+				return fmt.Errorf("failed to execute insert literal statement: %v", err)
+			}
 		}
 	}
 	return nil
 }
 
+const jobColumns = "uuid, done, name, solver, parameters, state, created_at"
+
 func (r* SqliteJobRepository) queryJob(uuid u.UUID) (*model.Job, error) {
-	jobRow, err := r.db.Query("SELECT uuid, done, name FROM jobs where uuid = ?", uuid.String())
+	jobRow, err := r.db.Query("SELECT " + jobColumns + " FROM jobs where uuid = ?", uuid.String())
 	if err != nil {
 		errDesc := fmt.Errorf("failed to query job: %v", err)
 		return nil, errDesc
 	}
 	defer jobRow.Close()
-	job := &model.Job{}
 	found := jobRow.Next()
 	if !found {
-		return nil, fmt.Errorf("unable to find job with uuid %s", uuid.String())
+		return nil, newNotFoundError("job", uuid)
+	}
+	return scanJob(jobRow)
+}
+
+// scanJob reads a row selected with jobColumns, filling in defaults for the
+// columns that rows written by older versions of the server leave empty.
+func scanJob(jobRow *sql.Rows) (*model.Job, error) {
+	job := &model.Job{}
+	var solver, parameters, state sql.NullString
+	var createdAt sql.NullInt64
+	jobRow.Scan(&job.Uuid, &job.Done, &job.Name, &solver, &parameters, &state, &createdAt)
+	job.Solver = model.SolverTypeGenetic
+	if solver.Valid {
+		job.Solver = model.SolverType(solver.String)
+	}
+	job.State = model.JobStateQueued
+	if state.Valid {
+		job.State = model.JobState(state.String)
+	} else if job.Done {
+		job.State = model.JobStateDone
+	}
+	if parameters.Valid {
+		err := json.Unmarshal([]byte(parameters.String), &job.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode solver parameters: %v", err)
+		}
 	}
-	jobRow.Scan(&job.Uuid, &job.Done, &job.Name)
+	job.CreatedAt = time.Unix(0, createdAt.Int64)
 	return job, nil
 }
 
 func (r* SqliteJobRepository) queryClauses(uuid u.UUID) ([]*model.Clause, error) {
-	clauseRows, err := r.db.Query("SELECT var1, var1negated, var2, var2negated, var3, var3negated FROM clauses WHERE UUID = ?", uuid.String())
+	literalRows, err := r.db.Query("SELECT clause, name, negated FROM literals WHERE uuid = ? ORDER BY clause, position", uuid.String())
+	if err != nil {
+		errDesc := fmt.Errorf("failed to query literals: %v", err)
+		return nil, errDesc
+	}
+	defer literalRows.Close()
+	clauses := []*model.Clause{}
+	for literalRows.Next() {
+		var index int
+		literal := &model.Variable{}
+		literalRows.Scan(&index, &literal.Name, &literal.Negated)
+		for len(clauses) <= index {
+			clauses = append(clauses, &model.Clause{Literals: []*model.Variable{}})
+		}
+		clauses[index].Literals = append(clauses[index].Literals, literal)
+	}
+	if len(clauses) == 0 {
+		return r.queryLegacyClauses(uuid)
+	}
+	return clauses, nil
+}
+
+// queryLegacyClauses reads jobs stored before clauses could have any number of
+// literals, when every clause was a row of exactly three in the clauses table.
+func (r* SqliteJobRepository) queryLegacyClauses(uuid u.UUID) ([]*model.Clause, error) {
+	clauseRows, err := r.db.Query("SELECT var1, var1negated, var2, var2negated, var3, var3negated FROM clauses WHERE UUID = ? ORDER BY id", uuid.String())
 	if err != nil {
 		errDesc := fmt.Errorf("failed to query clauses: %v", err)
 		return nil, errDesc
@@ -117,11 +348,9 @@ func (r* SqliteJobRepository) queryClauses(uuid u.UUID) ([]*model.Clause, error)
 	clauses := []*model.Clause{}
 	for clauseRows.Next() {
 		clause := &model.Clause{
-			Var1: &model.Variable{},
-			Var2: &model.Variable{},
-			Var3: &model.Variable{},
+			Literals: []*model.Variable{ {}, {}, {} },
 		}
-		clauseRows.Scan(&clause.Var1.Name, &clause.Var1.Negated, &clause.Var2.Name, &clause.Var2.Negated, &clause.Var3.Name, &clause.Var3.Negated)
+		clauseRows.Scan(&clause.Literals[0].Name, &clause.Literals[0].Negated, &clause.Literals[1].Name, &clause.Literals[1].Negated, &clause.Literals[2].Name, &clause.Literals[2].Negated)
 		clauses = append(clauses, clause)
 	}
 	return clauses, nil
@@ -135,10 +364,13 @@ func (r *SqliteJobRepository) openDatabase(dbName string) {
 	}
 	r.initJobsTable()
 	r.initClausesTable()
+	r.initLiteralsTable()
+	createIndex(r.db, "clauses", "uuid")
+	createIndex(r.db, "literals", "uuid")
 }
 
 func (r *SqliteJobRepository) initJobsTable() {
-	statement, err := r.db.Prepare("CREATE TABLE IF NOT EXISTS jobs (id INTEGER PRIMARY KEY, uuid STRING, done BOOLEAN, name STRING)")
+	statement, err := r.db.Prepare("CREATE TABLE IF NOT EXISTS jobs (id INTEGER PRIMARY KEY, uuid STRING, done BOOLEAN, name STRING, solver STRING, parameters STRING, state STRING, created_at INTEGER)")
 	if err != nil {
 		panic(fmt.Sprintf("Unable to create jobs table statement: %v", err))
 	}
@@ -147,6 +379,41 @@ func (r *SqliteJobRepository) initJobsTable() {
 	if err != nil {
 		panic(fmt.Sprintf("unable to execute create jobs table statement: %v", err))
 	}
+	addColumn(r.db, "jobs", "solver", "STRING")
+	addColumn(r.db, "jobs", "parameters", "STRING")
+	addColumn(r.db, "jobs", "state", "STRING")
+	addColumn(r.db, "jobs", "created_at", "INTEGER")
+	_, err = r.db.Exec("UPDATE jobs SET created_at = 0 WHERE created_at IS NULL")
+	if err != nil {
+		panic(fmt.Sprintf("unable to set creation time of older jobs: %v", err))
+	}
+	createIndex(r.db, "jobs", "uuid")
+	createIndex(r.db, "jobs", "created_at")
+}
+
+// addColumn upgrades tables created by older versions of the server, which
+// CREATE TABLE IF NOT EXISTS leaves untouched.
+func addColumn(db *sql.DB, table string, column string, columnType string) {
+	rows, err := db.Query(fmt.Sprintf("SELECT name FROM pragma_table_info('%s') WHERE name = ?", table), column)
+	if err != nil {
+		panic(fmt.Sprintf("unable to query columns of %s table: %v", table, err))
+	}
+	exists := rows.Next()
+	rows.Close()
+	if exists {
+		return
+	}
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, columnType))
+	if err != nil {
+		panic(fmt.Sprintf("unable to add %s column to %s table: %v", column, table, err))
+	}
+}
+
+func createIndex(db *sql.DB, table string, column string) {
+	_, err := db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_%s ON %s (%s)", table, column, table, column))
+	if err != nil {
+		panic(fmt.Sprintf("unable to create index on %s column of %s table: %v", column, table, err))
+	}
 }
 
 func (r *SqliteJobRepository) initClausesTable() {
@@ -160,3 +427,15 @@ func (r *SqliteJobRepository) initClausesTable() {
 		panic(fmt.Sprintf("unable to execute create clauses table statement: %v", err))
 	}
 }
+
+func (r *SqliteJobRepository) initLiteralsTable() {
+	statement, err := r.db.Prepare("CREATE TABLE IF NOT EXISTS literals (id INTEGER PRIMARY KEY, uuid STRING, clause INTEGER, position INTEGER, name STRING, negated BOOLEAN)")
+	if err != nil {
+		panic(fmt.Sprintf("Unable to create literals table statement: %v", err))
+	}
+	defer statement.Close()
+	_, err = statement.Exec()
+	if err != nil {
+		panic(fmt.Sprintf("unable to execute create literals table statement: %v", err))
+	}
+}
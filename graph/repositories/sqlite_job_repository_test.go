@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
@@ -24,6 +25,7 @@ func TestInsertJob(t *testing.T) {
 	}{
 		{ "no clauses", jobWithoutClauses(u.New()) },
 		{ "one clause", jobWithOneClause(u.New()) },
+		{ "clauses of mixed width", jobWithMixedWidthClauses(u.New()) },
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -38,7 +40,7 @@ func TestInsertJob(t *testing.T) {
 				t.Fatalf("failed to insert job: %v", err)
 			}
 			verifyJobRow(t, tc.job)
-			verifyClauseRows(t, tc.job)
+			verifyLiteralRows(t, tc.job)
 		})
 	}
 }
@@ -50,6 +52,13 @@ func TestFindJob(t *testing.T) {
 	}{
 		{ "no clauses", jobWithoutClauses(u.New()) },
 		{ "one clause", jobWithOneClause(u.New()) },
+		{ "clauses of mixed width", jobWithMixedWidthClauses(u.New()) },
+		{ "solver parameters", jobWithoutClauses(u.New(), func(j *model.Job) {
+			populationSize := 50
+			noise := 0.25
+			j.Solver = model.SolverTypeWalksat
+			j.Parameters = &model.SolverParameters{ PopulationSize: &populationSize, Noise: &noise }
+		}) },
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -72,6 +81,44 @@ func TestFindJob(t *testing.T) {
 	}
 }
 
+func TestOpenUpgradesLegacyJobsTable(t *testing.T) {
+	// arrange
+	legacyDbName := "testLegacyJobs.db"
+	os.Remove(legacyDbName)
+	defer os.Remove(legacyDbName)
+	db, _ := sql.Open("sqlite3", legacyDbName)
+	_, err := db.Exec("CREATE TABLE jobs (id INTEGER PRIMARY KEY, uuid STRING, done BOOLEAN, name STRING)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec("CREATE TABLE clauses (id INTEGER PRIMARY KEY, uuid STRING, var1 STRING, var1negated BOOLEAN, var2 STRING, var2negated BOOLEAN, var3 STRING, var3negated BOOLEAN)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := jobWithOneClause(u.New())
+	_, err = db.Exec("INSERT INTO jobs (uuid, done, name) VALUES (?, ?, ?)", want.Uuid.String(), want.Done, want.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	literals := want.Clauses[0].Literals
+	_, err = db.Exec("INSERT INTO clauses (uuid, var1, var1negated, var2, var2negated, var3, var3negated) VALUES (?, ?, ?, ?, ?, ?, ?)", want.Uuid.String(),
+		literals[0].Name, literals[0].Negated, literals[1].Name, literals[1].Negated, literals[2].Name, literals[2].Negated)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// act
+	sut := NewSqliteJobRepository(legacyDbName)
+
+	// assert
+	got, err := sut.FindJob(want.Uuid)
+	if err != nil {
+		t.Fatalf("failed to find job: %v", err)
+	}
+	verifyJobsAreEqual(t, got, want)
+}
+
 func TestMarkDone(t *testing.T) {
 	cases := []struct {
 		desc string
@@ -103,30 +150,148 @@ func TestMarkDone(t *testing.T) {
 				t.Fatalf("failed to find job: %v", err)
 			}
 			tc.want.Done = true
+			tc.want.State = model.JobStateDone
 			verifyJobsAreEqual(t, got, tc.want)
 		})
 	}
 }
 
+func TestUpdateState(t *testing.T) {
+	cases := []struct {
+		desc string
+		state model.JobState
+		wantDone bool
+	}{
+		{ "queued job marked as running", model.JobStateRunning, false },
+		{ "running job marked as cancelled", model.JobStateCancelled, true },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := NewSqliteJobRepository(dbName)
+			want := jobWithoutClauses(u.New())
+			err := sut.InsertJob(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// act
+			err = sut.UpdateState(want, tc.state)
+
+			// assert
+			if err != nil {
+				t.Fatalf("unable to update job state: %v", err)
+			}
+			got, err := sut.FindJob(want.Uuid)
+			if err != nil {
+				t.Fatalf("failed to find job: %v", err)
+			}
+			if got.State != tc.state {
+				t.Errorf("wrong state: got %s want %s", got.State, tc.state)
+			}
+			if got.Done != tc.wantDone {
+				t.Errorf("wrong done: got %t want %t", got.Done, tc.wantDone)
+			}
+		})
+	}
+}
+
+func TestFindJobsByState(t *testing.T) {
+	// arrange
+	statesDbName := "testJobStates.db"
+	os.Remove(statesDbName)
+	defer os.Remove(statesDbName)
+	sut := NewSqliteJobRepository(statesDbName)
+	queued := jobWithOneClause(u.New())
+	running := jobWithoutClauses(u.New(), func(j *model.Job) {
+		j.State = model.JobStateRunning
+	})
+	done := jobWithoutClauses(u.New(), func(j *model.Job) {
+		j.Done = true
+		j.State = model.JobStateDone
+	})
+	for _, job := range []*model.Job{queued, running, done} {
+		err := sut.InsertJob(job)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	legacy := jobWithoutClauses(u.New())
+	db, _ := sql.Open("sqlite3", statesDbName)
+	_, err := db.Exec("INSERT INTO jobs (uuid, done, name) VALUES (?, ?, ?)", legacy.Uuid.String(), legacy.Done, legacy.Name)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		desc string
+		state model.JobState
+		want []*model.Job
+	}{
+		{ "queued jobs include rows without a state", model.JobStateQueued, []*model.Job{ queued, legacy } },
+		{ "running jobs", model.JobStateRunning, []*model.Job{ running } },
+		{ "done jobs", model.JobStateDone, []*model.Job{ done } },
+		{ "no cancelled jobs", model.JobStateCancelled, []*model.Job{} },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// act
+			got, err := sut.FindJobsByState(tc.state)
+
+			// assert
+			if err != nil {
+				t.Fatalf("failed to find jobs: %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("wrong number of jobs: got %d want %d", len(got), len(tc.want))
+			}
+			for index, want := range tc.want {
+				verifyJobsAreEqual(t, got[index], want)
+			}
+		})
+	}
+}
+
 func verifyJobsAreEqual(t testing.TB, got *model.Job, want *model.Job) {
-	if got.Uuid != want.Uuid || got.Done != want.Done || got.Name != want.Name {
-		t.Fatalf("got (%s %t %s) want (%s %t %s)", got.Uuid.String(), got.Done, got.Name, want.Uuid.String(), want.Done, want.Name)
+	if got.Uuid != want.Uuid || got.Done != want.Done || got.Name != want.Name || got.Solver != want.Solver || got.State != want.State {
+		t.Fatalf("got (%s %t %s %s %s) want (%s %t %s %s %s)", got.Uuid.String(), got.Done, got.Name, got.Solver, got.State,
+			want.Uuid.String(), want.Done, want.Name, want.Solver, want.State)
 	}
+	verifyParametersAreEqual(t, got.Parameters, want.Parameters)
 	if len(got.Clauses) != len(want.Clauses) {
 		t.Fatalf("wrong number of clauses: got %d want %d", len(got.Clauses), len(want.Clauses))
 	}
 	for index, clause := range want.Clauses {
-		gotClause := got.Clauses[index]
-		if gotClause.Var1.Name != clause.Var1.Name || gotClause.Var1.Negated != clause.Var1.Negated ||
-				gotClause.Var2.Name != clause.Var2.Name || gotClause.Var2.Negated != clause.Var2.Negated ||
-				gotClause.Var3.Name != clause.Var3.Name || gotClause.Var3.Negated != clause.Var3.Negated {
-			t.Errorf("clause %d got (%s %t %s %t %s %t) want (%s %t %s %t %s %t)", index,
-				gotClause.Var1.Name, gotClause.Var1.Negated, gotClause.Var2.Name, gotClause.Var2.Negated, gotClause.Var3.Name, gotClause.Var3.Negated,
-				clause.Var1.Name, clause.Var1.Negated, clause.Var2.Name, clause.Var2.Negated, clause.Var3.Name, clause.Var3.Negated)
+		verifyLiteralsAreEqual(t, index, got.Clauses[index].Literals, clause.Literals)
+	}
+}
+
+func verifyLiteralsAreEqual(t testing.TB, index int, got []*model.Variable, want []*model.Variable) {
+	if len(got) != len(want) {
+		t.Fatalf("wrong number of literals in clause %d: got %d want %d", index, len(got), len(want))
+	}
+	for position, literal := range want {
+		if got[position].Name != literal.Name || got[position].Negated != literal.Negated {
+			t.Errorf("clause %d literal %d got (%s %t) want (%s %t)", index, position,
+				got[position].Name, got[position].Negated, literal.Name, literal.Negated)
 		}
 	}
 }
 
+func verifyParametersAreEqual(t testing.TB, got *model.SolverParameters, want *model.SolverParameters) {
+	if (got == nil) != (want == nil) {
+		t.Fatalf("nil expectations violated for parameters: got '%t' want '%t'", got == nil, want == nil)
+	}
+	if want == nil {
+		return
+	}
+	gotJson, _ := json.Marshal(got)
+	wantJson, _ := json.Marshal(want)
+	if string(gotJson) != string(wantJson) {
+		t.Fatalf("got parameters %s want %s", gotJson, wantJson)
+	}
+}
+
 func verifyJobRow(t testing.TB, job *model.Job) {
 	db, _ := sql.Open("sqlite3", dbName)
 	defer db.Close()
@@ -150,45 +315,34 @@ func verifyJobRow(t testing.TB, job *model.Job) {
 	}
 }
 
-func verifyClauseRows(t testing.TB, job *model.Job) {
+func verifyLiteralRows(t testing.TB, job *model.Job) {
 	db, _ := sql.Open("sqlite3", dbName)
 	defer db.Close()
-	rows, _ := db.Query("SELECT var1, var1negated, var2, var2negated, var3, var3negated FROM clauses WHERE uuid = ?", job.Uuid.String())
-	hasRow := rows.Next()
-	for _, clause := range job.Clauses {
-		if !hasRow {
-			t.Fatal("not enough clause rows")
+	rows, _ := db.Query("SELECT clause, name, negated FROM literals WHERE uuid = ? ORDER BY clause, position", job.Uuid.String())
+	defer rows.Close()
+	got := make([][]*model.Variable, len(job.Clauses))
+	for rows.Next() {
+		var index int
+		literal := &model.Variable{}
+		err := rows.Scan(&index, &literal.Name, &literal.Negated)
+		if err != nil {
+			t.Fatalf("unable to read row: %v", err)
 		}
-		verifyClauseRow(t, clause, rows)
-		hasRow = rows.Next()
-	}
-	if hasRow {
-		t.Fatal("returned too many clause rows")
-	}
-}
-
-func verifyClauseRow(t testing.TB, clause *model.Clause, rows *sql.Rows) {
-	got := &model.Clause{
-		Var1: &model.Variable{},
-		Var2: &model.Variable{},
-		Var3: &model.Variable{},
-	}
-	err := rows.Scan(&got.Var1.Name, &got.Var1.Negated, &got.Var2.Name, &got.Var2.Negated, &got.Var3.Name, &got.Var3.Negated)
-	if err != nil {
-		t.Fatalf("unable to read row: %v", err)
+		if index >= len(got) {
+			t.Fatalf("literal row for clause %d of a job with %d clauses", index, len(got))
+		}
+		got[index] = append(got[index], literal)
 	}
-	if got.Var1.Name != clause.Var1.Name || got.Var1.Negated != clause.Var1.Negated ||
-			got.Var2.Name != clause.Var2.Name || got.Var2.Negated != clause.Var2.Negated ||
-			got.Var3.Name != clause.Var3.Name || got.Var3.Negated != clause.Var3.Negated {
-		t.Fatalf("got (%s, %t, %s, %t, %s, %t) want (%s, %t, %s, %t, %s, %t)",
-			got.Var1.Name, got.Var1.Negated, got.Var2.Name, got.Var2.Negated, got.Var3.Name, got.Var3.Negated,
-			clause.Var1.Name, clause.Var1.Negated, clause.Var2.Name, clause.Var2.Negated, clause.Var3.Name, clause.Var3.Negated)
+	for index, clause := range job.Clauses {
+		verifyLiteralsAreEqual(t, index, got[index], clause.Literals)
 	}
 }
 
 func jobWithoutClauses(uuid u.UUID, postFuncs ...func(*model.Job)) *model.Job {
 	job := &model.Job{
 		Uuid: uuid,
+		Solver: model.SolverTypeGenetic,
+		State: model.JobStateQueued,
 		Name: fmt.Sprintf("test-%s", uuid.String()),
 		Clauses: []*model.Clause{},
 	}
@@ -201,19 +355,23 @@ func jobWithoutClauses(uuid u.UUID, postFuncs ...func(*model.Job)) *model.Job {
 func jobWithOneClause(uuid u.UUID, postFuncs ...func(*model.Job)) *model.Job {
 	job := &model.Job{
 		Uuid: uuid,
+		Solver: model.SolverTypeGenetic,
+		State: model.JobStateQueued,
 		Name: fmt.Sprintf("test-%s", uuid.String()),
 		Clauses: []*model.Clause{ {
-				Var1: &model.Variable{
-					Name: "v1",
-					Negated: true,
-				},
-				Var2: &model.Variable{
-					Name: "v2",
-					Negated: false,
-				},
-				Var3: &model.Variable{
-					Name: "v3",
-					Negated: true,
+				Literals: []*model.Variable{
+					{
+						Name: "v1",
+						Negated: true,
+					},
+					{
+						Name: "v2",
+						Negated: false,
+					},
+					{
+						Name: "v3",
+						Negated: true,
+					},
 				},
 			},
 		},
@@ -223,3 +381,18 @@ func jobWithOneClause(uuid u.UUID, postFuncs ...func(*model.Job)) *model.Job {
 	}
 	return job
 }
+
+func jobWithMixedWidthClauses(uuid u.UUID, postFuncs ...func(*model.Job)) *model.Job {
+	job := jobWithoutClauses(uuid, postFuncs...)
+	job.Clauses = []*model.Clause{
+		{ Literals: []*model.Variable{ { Name: "v1", Negated: true } } },
+		{ Literals: []*model.Variable{ { Name: "v1", Negated: false }, { Name: "v2", Negated: true } } },
+		{ Literals: []*model.Variable{
+			{ Name: "v1", Negated: false },
+			{ Name: "v2", Negated: false },
+			{ Name: "v3", Negated: true },
+			{ Name: "v4", Negated: false },
+		} },
+	}
+	return job
+}
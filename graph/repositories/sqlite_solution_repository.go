@@ -0,0 +1,265 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	u "github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+type SqliteSolutionRepository struct {
+	db *sql.DB
+}
+
+// solutionTables names a table of solutions and the table holding their
+// variables; final solutions and incumbents are stored alike.
+type solutionTables struct {
+	solutions string
+	variables string
+}
+
+var (
+	finalTables = solutionTables{solutions: "solutions", variables: "solved_variables"}
+	incumbentTables = solutionTables{solutions: "incumbents", variables: "incumbent_variables"}
+)
+
+func NewSqliteSolutionRepository(dbName string) *SqliteSolutionRepository {
+	repo := &SqliteSolutionRepository{}
+	repo.openDatabase(dbName)
+	return repo
+}
+
+func (r* SqliteSolutionRepository) FindSolution(uuid u.UUID) (*model.Solution, error) {
+	solution, err := r.find(uuid, finalTables, "solution")
+	if err != nil {
+		return nil, err
+	}
+	solution.History, err = r.queryHistory(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return solution, nil
+}
+
+func (r* SqliteSolutionRepository) InsertSolution(solution *model.Solution) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to create insert solution transaction: %v", err)
+	}
+	err = r.deleteRows(solution.Uuid, finalTables, tx)
+	if err == nil {
+		err = r.deleteHistoryRows(solution.Uuid, tx)
+	}
+	if err == nil {
+		err = r.insert(solution, finalTables, tx)
+	}
+	if err == nil {
+		err = r.insertHistoryRows(solution, tx)
+	}
+	if err == nil {
+		err = r.deleteRows(solution.Uuid, incumbentTables, tx)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r* SqliteSolutionRepository) FindIncumbent(uuid u.UUID) (*model.Solution, error) {
+	return r.find(uuid, incumbentTables, "incumbent")
+}
+
+func (r* SqliteSolutionRepository) SaveIncumbent(solution *model.Solution) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to create save incumbent transaction: %v", err)
+	}
+	err = r.deleteRows(solution.Uuid, incumbentTables, tx)
+	if err == nil {
+		err = r.insert(solution, incumbentTables, tx)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r* SqliteSolutionRepository) find(uuid u.UUID, tables solutionTables, kind string) (*model.Solution, error) {
+	solution, err := r.querySolution(uuid, tables, kind)
+	if err != nil {
+		return nil, err
+	}
+	solution.Variables, err = r.queryVariables(uuid, tables)
+	if err != nil {
+		return nil, err
+	}
+	return solution, nil
+}
+
+func (r* SqliteSolutionRepository) insert(solution *model.Solution, tables solutionTables, tx *sql.Tx) error {
+	err := r.insertSolutionRow(solution, tables, tx)
+	if err != nil {
+		return err
+	}
+	return r.insertVariableRows(solution, tables, tx)
+}
+
+func (r* SqliteSolutionRepository) deleteRows(uuid u.UUID, tables solutionTables, tx *sql.Tx) error {
+	for _, table := range []string{tables.solutions, tables.variables} {
+		_, err := tx.Exec("DELETE FROM " + table + " WHERE uuid = ?", uuid.String())
+		if err != nil {
+			return fmt.Errorf("failed to delete from %s: %v", table, err)
+		}
+	}
+	return nil
+}
+
+func (r* SqliteSolutionRepository) deleteHistoryRows(uuid u.UUID, tx *sql.Tx) error {
+	_, err := tx.Exec("DELETE FROM solution_history WHERE uuid = ?", uuid.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete from solution_history: %v", err)
+	}
+	return nil
+}
+
+func (r* SqliteSolutionRepository) insertSolutionRow(solution *model.Solution, tables solutionTables, tx *sql.Tx) error {
+	statement, err := tx.Prepare("INSERT INTO " + tables.solutions + " (uuid, score, cycles, elapsed, status, solved_by) VALUES (?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return fmt.Errorf("failed to create insert solution statement: %v", err)
+	}
+	defer statement.Close()
+	var solvedBy sql.NullString
+	if solution.SolvedBy != nil {
+		solvedBy = sql.NullString{String: solution.SolvedBy.String(), Valid: true}
+	}
+	_, err = statement.Exec(solution.Uuid.String(), solution.Score, solution.Cycles, int64(solution.Elapsed), solution.Status.String(), solvedBy)
+	if err != nil {
+		return fmt.Errorf("failed to execute insert solution statement: %v", err)
+	}
+	return nil
+}
+
+func (r* SqliteSolutionRepository) insertVariableRows(solution *model.Solution, tables solutionTables, tx *sql.Tx) error {
+	statement, err := tx.Prepare("INSERT INTO " + tables.variables + " (uuid, name, value) VALUES (?, ?, ?)")
+	if err != nil {
+		return fmt.Errorf("failed to create insert solved variable statement: %v", err)
+	}
+	defer statement.Close()
+	for _, variable := range solution.Variables {
+		_, err = statement.Exec(solution.Uuid.String(), variable.Name, variable.Value)
+		if err != nil {
+			return fmt.Errorf("failed to execute insert solved variable statement: %v", err)
+		}
+	}
+	return nil
+}
+
+func (r* SqliteSolutionRepository) insertHistoryRows(solution *model.Solution, tx *sql.Tx) error {
+	statement, err := tx.Prepare("INSERT INTO solution_history (uuid, cycle, best_score, average_score, diversity, temperature) VALUES (?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return fmt.Errorf("failed to create insert history statement: %v", err)
+	}
+	defer statement.Close()
+	for _, point := range solution.History {
+		_, err = statement.Exec(solution.Uuid.String(), point.Cycle, point.BestScore, point.AverageScore, point.Diversity, point.Temperature)
+		if err != nil {
+			return fmt.Errorf("failed to execute insert history statement: %v", err)
+		}
+	}
+	return nil
+}
+
+func (r* SqliteSolutionRepository) querySolution(uuid u.UUID, tables solutionTables, kind string) (*model.Solution, error) {
+	solutionRow, err := r.db.Query("SELECT uuid, score, cycles, elapsed, status, solved_by FROM " + tables.solutions + " WHERE uuid = ?", uuid.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query solution: %v", err)
+	}
+	defer solutionRow.Close()
+	found := solutionRow.Next()
+	if !found {
+		return nil, newNotFoundError(kind, uuid)
+	}
+	solution := &model.Solution{}
+	var elapsed int64
+	var status string
+	var solvedBy sql.NullString
+	err = solutionRow.Scan(&solution.Uuid, &solution.Score, &solution.Cycles, &elapsed, &status, &solvedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read solution: %v", err)
+	}
+	solution.Elapsed = time.Duration(elapsed)
+	solution.Status = model.SolutionStatus(status)
+	if solvedBy.Valid {
+		solver := model.SolverType(solvedBy.String)
+		solution.SolvedBy = &solver
+	}
+	return solution, nil
+}
+
+func (r* SqliteSolutionRepository) queryVariables(uuid u.UUID, tables solutionTables) ([]*model.SolvedVariable, error) {
+	variableRows, err := r.db.Query("SELECT name, value FROM " + tables.variables + " WHERE uuid = ? ORDER BY id", uuid.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query solved variables: %v", err)
+	}
+	defer variableRows.Close()
+	variables := []*model.SolvedVariable{}
+	for variableRows.Next() {
+		variable := &model.SolvedVariable{}
+		variableRows.Scan(&variable.Name, &variable.Value)
+		variables = append(variables, variable)
+	}
+	return variables, nil
+}
+
+func (r* SqliteSolutionRepository) queryHistory(uuid u.UUID) ([]*model.HistoryPoint, error) {
+	historyRows, err := r.db.Query("SELECT cycle, best_score, average_score, diversity, temperature FROM solution_history WHERE uuid = ? ORDER BY id", uuid.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %v", err)
+	}
+	defer historyRows.Close()
+	history := []*model.HistoryPoint{}
+	for historyRows.Next() {
+		point := &model.HistoryPoint{}
+		err = historyRows.Scan(&point.Cycle, &point.BestScore, &point.AverageScore, &point.Diversity, &point.Temperature)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history: %v", err)
+		}
+		history = append(history, point)
+	}
+	return history, nil
+}
+
+func (r *SqliteSolutionRepository) openDatabase(dbName string) {
+	var err error
+	r.db, err = sql.Open("sqlite3", dbName)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to open solutions database: %v", err))
+	}
+	for _, tables := range []solutionTables{finalTables, incumbentTables} {
+		r.createTable("CREATE TABLE IF NOT EXISTS " + tables.solutions + " (id INTEGER PRIMARY KEY, uuid STRING, score REAL, cycles INTEGER, elapsed INTEGER, status STRING, solved_by STRING)", tables.solutions)
+		addColumn(r.db, tables.solutions, "solved_by", "STRING")
+		r.createTable("CREATE TABLE IF NOT EXISTS " + tables.variables + " (id INTEGER PRIMARY KEY, uuid STRING, name STRING, value BOOLEAN)", tables.variables)
+		createIndex(r.db, tables.solutions, "uuid")
+		createIndex(r.db, tables.variables, "uuid")
+	}
+	r.createTable("CREATE TABLE IF NOT EXISTS solution_history (id INTEGER PRIMARY KEY, uuid STRING, cycle INTEGER, best_score REAL, average_score REAL, diversity REAL, temperature REAL)", "solution_history")
+	addColumn(r.db, "solution_history", "temperature", "REAL")
+	createIndex(r.db, "solution_history", "uuid")
+}
+
+func (r *SqliteSolutionRepository) createTable(query string, table string) {
+	statement, err := r.db.Prepare(query)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to create %s table statement: %v", table, err))
+	}
+	defer statement.Close()
+	_, err = statement.Exec()
+	if err != nil {
+		panic(fmt.Sprintf("unable to execute create %s table statement: %v", table, err))
+	}
+}
@@ -0,0 +1,238 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	u "github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestFindSolution(t *testing.T) {
+	cases := []struct {
+		desc string
+		want *model.Solution
+	}{
+		{ "no variables", solutionWithoutVariables(u.New()) },
+		{ "variables keep their order", solutionWithVariables(u.New()) },
+		{ "history keeps its order", solutionWithHistory(u.New()) },
+		{ "portfolio member is kept", solutionSolvedBy(u.New(), model.SolverTypeCdcl) },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := NewSqliteSolutionRepository(dbName)
+			err := sut.InsertSolution(tc.want)
+			if err != nil {
+				t.Fatalf("failed to insert solution: %v", err)
+			}
+
+			// act
+			got, err := sut.FindSolution(tc.want.Uuid)
+
+			// assert
+			if err != nil {
+				t.Fatalf("failed to find solution: %v", err)
+			}
+			verifySolutionsAreEqual(t, got, tc.want)
+		})
+	}
+}
+
+func TestFindSolutionAfterReopening(t *testing.T) {
+	// arrange
+	want := solutionWithVariables(u.New())
+	err := NewSqliteSolutionRepository(dbName).InsertSolution(want)
+	if err != nil {
+		t.Fatalf("failed to insert solution: %v", err)
+	}
+	sut := NewSqliteSolutionRepository(dbName)
+
+	// act
+	got, err := sut.FindSolution(want.Uuid)
+
+	// assert
+	if err != nil {
+		t.Fatalf("failed to find solution: %v", err)
+	}
+	verifySolutionsAreEqual(t, got, want)
+}
+
+func TestSaveIncumbent(t *testing.T) {
+	// arrange
+	sut := NewSqliteSolutionRepository(dbName)
+	uuid := u.New()
+	first := solutionWithoutVariables(uuid)
+	want := solutionWithVariables(uuid)
+	want.Status = model.SolutionStatusUnknown
+
+	// act
+	err := sut.SaveIncumbent(first)
+	if err == nil {
+		err = sut.SaveIncumbent(want)
+	}
+	got, findErr := NewSqliteSolutionRepository(dbName).FindIncumbent(uuid)
+
+	// assert
+	if err != nil {
+		t.Fatalf("failed to save incumbent: %v", err)
+	}
+	if findErr != nil {
+		t.Fatalf("failed to find incumbent: %v", findErr)
+	}
+	verifySolutionsAreEqual(t, got, want)
+	_, err = sut.FindSolution(uuid)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("incumbent was found as a solution: %v", err)
+	}
+}
+
+func TestInsertSolutionDropsIncumbent(t *testing.T) {
+	// arrange
+	sut := NewSqliteSolutionRepository(dbName)
+	uuid := u.New()
+	err := sut.SaveIncumbent(solutionWithVariables(uuid))
+	if err != nil {
+		t.Fatalf("failed to save incumbent: %v", err)
+	}
+
+	// act
+	err = sut.InsertSolution(solutionWithVariables(uuid))
+
+	// assert
+	if err != nil {
+		t.Fatalf("failed to insert solution: %v", err)
+	}
+	_, err = sut.FindIncumbent(uuid)
+	want := "unable to find incumbent with uuid " + uuid.String()
+	if err == nil || err.Error() != want {
+		t.Errorf("wrong error: got '%v' want '%s'", err, want)
+	}
+}
+
+func TestInsertSolutionReplacesEarlierSolution(t *testing.T) {
+	// arrange
+	sut := NewSqliteSolutionRepository(dbName)
+	uuid := u.New()
+	err := sut.InsertSolution(solutionWithHistory(uuid))
+	if err != nil {
+		t.Fatalf("failed to insert solution: %v", err)
+	}
+	want := solutionWithHistory(uuid)
+	want.Score = 1.0
+	want.Cycles = 84
+
+	// act
+	err = sut.InsertSolution(want)
+
+	// assert
+	if err != nil {
+		t.Fatalf("failed to insert solution: %v", err)
+	}
+	got, err := sut.FindSolution(uuid)
+	if err != nil {
+		t.Fatalf("failed to find solution: %v", err)
+	}
+	verifySolutionsAreEqual(t, got, want)
+}
+
+func TestFindSolutionWhenMissing(t *testing.T) {
+	// arrange
+	sut := NewSqliteSolutionRepository(dbName)
+	uuid := u.New()
+
+	// act
+	_, err := sut.FindSolution(uuid)
+
+	// assert
+	if err == nil {
+		t.Fatal("failed to return error")
+	}
+	want := "unable to find solution with uuid " + uuid.String()
+	if err.Error() != want {
+		t.Errorf("wrong error: got '%s' want '%s'", err.Error(), want)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("error does not match ErrNotFound: %v", err)
+	}
+}
+
+func verifySolutionsAreEqual(t testing.TB, got *model.Solution, want *model.Solution) {
+	if got.Uuid != want.Uuid || got.Score != want.Score || got.Cycles != want.Cycles || got.Elapsed != want.Elapsed || got.Status != want.Status {
+		t.Fatalf("got (%s %f %d %s %s) want (%s %f %d %s %s)", got.Uuid.String(), got.Score, got.Cycles, got.Elapsed, got.Status,
+			want.Uuid.String(), want.Score, want.Cycles, want.Elapsed, want.Status)
+	}
+	if (got.SolvedBy == nil) != (want.SolvedBy == nil) || (got.SolvedBy != nil && *got.SolvedBy != *want.SolvedBy) {
+		t.Errorf("wrong solvedBy: got %v want %v", got.SolvedBy, want.SolvedBy)
+	}
+	if len(got.Variables) != len(want.Variables) {
+		t.Fatalf("wrong number of variables: got %d want %d", len(got.Variables), len(want.Variables))
+	}
+	for index, variable := range want.Variables {
+		if got.Variables[index].Name != variable.Name || got.Variables[index].Value != variable.Value {
+			t.Errorf("variable %d got (%s %t) want (%s %t)", index, got.Variables[index].Name, got.Variables[index].Value, variable.Name, variable.Value)
+		}
+	}
+	if len(got.History) != len(want.History) {
+		t.Fatalf("wrong number of history points: got %d want %d", len(got.History), len(want.History))
+	}
+	for index, point := range want.History {
+		if got.History[index].Cycle != point.Cycle || got.History[index].BestScore != point.BestScore ||
+			!equalOptionalFloats(got.History[index].AverageScore, point.AverageScore) || !equalOptionalFloats(got.History[index].Diversity, point.Diversity) ||
+			!equalOptionalFloats(got.History[index].Temperature, point.Temperature) {
+			t.Errorf("history point %d got %+v want %+v", index, *got.History[index], *point)
+		}
+	}
+}
+
+func equalOptionalFloats(got *float64, want *float64) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return *got == *want
+}
+
+func solutionWithoutVariables(uuid u.UUID) *model.Solution {
+	return &model.Solution{
+		Uuid: uuid,
+		Variables: []*model.SolvedVariable{},
+		Elapsed: 0,
+		Status: model.SolutionStatusCancelled,
+	}
+}
+
+func solutionWithVariables(uuid u.UUID) *model.Solution {
+	return &model.Solution{
+		Uuid: uuid,
+		Variables: []*model.SolvedVariable{
+			{ Name: "v2", Value: true },
+			{ Name: "v1", Value: false },
+			{ Name: "v3", Value: true },
+		},
+		Score: 0.875,
+		Cycles: 42,
+		Elapsed: 1500 * time.Millisecond,
+		Status: model.SolutionStatusSatisfiable,
+	}
+}
+
+func solutionWithHistory(uuid u.UUID) *model.Solution {
+	solution := solutionWithVariables(uuid)
+	averageScore := 0.5
+	diversity := 0.25
+	temperature := 1.5
+	solution.History = []*model.HistoryPoint{
+		{ Cycle: 0, BestScore: 0.625, AverageScore: &averageScore, Diversity: &diversity },
+		{ Cycle: 21, BestScore: 0.75, Temperature: &temperature },
+		{ Cycle: 42, BestScore: 0.875 },
+	}
+	return solution
+}
+
+func solutionSolvedBy(uuid u.UUID, solver model.SolverType) *model.Solution {
+	solution := solutionWithVariables(uuid)
+	solution.SolvedBy = &solver
+	return solution
+}
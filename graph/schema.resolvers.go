@@ -5,8 +5,10 @@ package graph
 
 import (
 	"context"
+	"strings"
 
 	u "github.com/google/uuid"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/dimacs"
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph/generated"
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
 )
@@ -21,9 +23,57 @@ func (r *jobResolver) UUID(ctx context.Context, obj *model.Job) (string, error)
 	return obj.Uuid.String(), nil
 }
 
+// Solution is the resolver for the solution field.
+func (r *jobResolver) Solution(ctx context.Context, obj *model.Job) (*model.Solution, error) {
+	return r.JobDispatcher.JobSolution(obj)
+}
+
+// CurrentBest is the resolver for the currentBest field.
+func (r *jobResolver) CurrentBest(ctx context.Context, obj *model.Job) (*model.Solution, error) {
+	return r.JobDispatcher.CurrentBest(obj)
+}
+
+// QueuePosition is the resolver for the queuePosition field.
+func (r *jobResolver) QueuePosition(ctx context.Context, obj *model.Job) (*int, error) {
+	return r.JobDispatcher.QueuePosition(obj.Uuid), nil
+}
+
+// UUID is the resolver for the uuid field.
+func (r *jobProgressResolver) UUID(ctx context.Context, obj *model.JobProgress) (string, error) {
+	return obj.Uuid.String(), nil
+}
+
+// Elapsed is the resolver for the elapsed field.
+func (r *jobProgressResolver) Elapsed(ctx context.Context, obj *model.JobProgress) (int, error) {
+	return int(obj.Elapsed.Milliseconds()), nil
+}
+
 // CreateJob is the resolver for the createJob field.
 func (r *mutationResolver) CreateJob(ctx context.Context, input model.NewJob) (*model.Job, error) {
-	return r.JobDispatcher.DispatchJob(&input), nil
+	return r.JobDispatcher.DispatchJob(&input)
+}
+
+// CreateJobFromDimacs is the resolver for the createJobFromDimacs field.
+func (r *mutationResolver) CreateJobFromDimacs(ctx context.Context, name string, cnf string, solver *model.SolverType, parameters *model.NewSolverParameters) (*model.Job, error) {
+	formula, err := dimacs.Parse(strings.NewReader(cnf))
+	if err != nil {
+		return nil, err
+	}
+	return r.JobDispatcher.DispatchJob(&model.NewJob{
+		Name:       name,
+		Clauses:    formula.NewClauses(),
+		Solver:     solver,
+		Parameters: parameters,
+	})
+}
+
+// CancelJob is the resolver for the cancelJob field.
+func (r *mutationResolver) CancelJob(ctx context.Context, uuid string) (*model.Job, error) {
+	actualUuid, err := u.Parse(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return r.JobDispatcher.CancelJob(actualUuid)
 }
 
 // Job is the resolver for the job field.
@@ -35,6 +85,11 @@ func (r *queryResolver) Job(ctx context.Context, uuid string) (*model.Job, error
 	return r.JobDispatcher.FindJob(actualUuid)
 }
 
+// Jobs is the resolver for the jobs field.
+func (r *queryResolver) Jobs(ctx context.Context, first *int, after *string, filter *model.JobFilter, orderBy *model.JobOrder) (*model.JobConnection, error) {
+	return r.JobDispatcher.Jobs(first, after, filter, orderBy)
+}
+
 // Solution is the resolver for the solution field.
 func (r *queryResolver) Solution(ctx context.Context, uuid string) (*model.Solution, error) {
 	actualUuid, err := u.Parse(uuid)
@@ -59,9 +114,31 @@ func (r *solutionResolver) Elapsed(ctx context.Context, obj *model.Solution) (in
 	return int(obj.Elapsed.Milliseconds()), nil
 }
 
+// Dimacs is the resolver for the dimacs field.
+func (r *solutionResolver) Dimacs(ctx context.Context, obj *model.Solution) (string, error) {
+	return dimacs.FormatSolution(obj), nil
+}
+
+// Job is the resolver for the job field.
+func (r *solutionResolver) Job(ctx context.Context, obj *model.Solution) (*model.Job, error) {
+	return r.JobDispatcher.FindJob(obj.Uuid)
+}
+
+// JobProgress is the resolver for the jobProgress field.
+func (r *subscriptionResolver) JobProgress(ctx context.Context, uuid string) (<-chan *model.JobProgress, error) {
+	actualUuid, err := u.Parse(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return r.JobDispatcher.SubscribeProgress(ctx, actualUuid)
+}
+
 // Job returns generated.JobResolver implementation.
 func (r *Resolver) Job() generated.JobResolver { return &jobResolver{r} }
 
+// JobProgress returns generated.JobProgressResolver implementation.
+func (r *Resolver) JobProgress() generated.JobProgressResolver { return &jobProgressResolver{r} }
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
@@ -71,7 +148,12 @@ func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 // Solution returns generated.SolutionResolver implementation.
 func (r *Resolver) Solution() generated.SolutionResolver { return &solutionResolver{r} }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
 type jobResolver struct{ *Resolver }
+type jobProgressResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type solutionResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
@@ -2,7 +2,11 @@ package graph
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	u "github.com/google/uuid"
 
@@ -13,7 +17,7 @@ import (
 )
 
 type mutationResolverContext struct {
-	solver solvers.Solver
+	registry *solvers.Registry
 	jobRepository *repositories.InMemoryJobRepository
 	solutionRepository *repositories.InMemorySolutionRepository
 	jobFactory *factories.JobFactory
@@ -22,21 +26,27 @@ type mutationResolverContext struct {
 	resolver *Resolver
 	mutationResolver *mutationResolver
 	queryResolver *queryResolver
+	jobResolver *jobResolver
+	solutionResolver *solutionResolver
+	subscriptionResolver *subscriptionResolver
 }
 
 func newMutationResolverContext() *mutationResolverContext {
 	jobRepository := &repositories.InMemoryJobRepository{}
 	solutionRepository := &repositories.InMemorySolutionRepository{}
-	jobFactory := &factories.JobFactory{}
+	jobFactory := &factories.JobFactory{DefaultSolver: model.SolverTypeNaive}
 	solutionFactory := &factories.SolutionFactory{}
-	solver := solvers.NewNaiveSolver(
-		solutionFactory,
-	)
+	registry := solvers.NewRegistry()
+	registry.Register(model.SolverTypeNaive, func(parameters *model.SolverParameters) solvers.Solver {
+		return solvers.NewNaiveSolver(solutionFactory)
+	})
 	jobDispatcher := NewJobDispatcher(
-		solver,
+		registry,
 		jobRepository,
 		solutionRepository,
 		jobFactory,
+		solutionFactory,
+		1,
 	)
 	resolver := &Resolver{
 		JobDispatcher: jobDispatcher,
@@ -47,8 +57,17 @@ func newMutationResolverContext() *mutationResolverContext {
 	queryResolver := &queryResolver{
 		Resolver: resolver,
 	}
+	jobResolver := &jobResolver{
+		Resolver: resolver,
+	}
+	solutionResolver := &solutionResolver{
+		Resolver: resolver,
+	}
+	subscriptionResolver := &subscriptionResolver{
+		Resolver: resolver,
+	}
 	return &mutationResolverContext{
-		solver: solver,
+		registry: registry,
 		jobRepository: jobRepository,
 		solutionRepository: solutionRepository,
 		jobFactory: jobFactory,
@@ -57,6 +76,9 @@ func newMutationResolverContext() *mutationResolverContext {
 		resolver: resolver,
 		mutationResolver: mutationResolver,
 		queryResolver: queryResolver,
+		jobResolver: jobResolver,
+		solutionResolver: solutionResolver,
+		subscriptionResolver: subscriptionResolver,
 	}
 }
 
@@ -82,6 +104,739 @@ func TestCreateJob(t *testing.T) {
 	}
 }
 
+func TestCreateJobRecordsSolver(t *testing.T) {
+	populationSize := 20
+	cases := []struct {
+		desc string
+		solver *model.SolverType
+		parameters *model.NewSolverParameters
+		want model.SolverType
+	}{
+		{ "default solver is used when none is given", nil, nil, model.SolverTypeNaive },
+		{ "requested solver and parameters are recorded", solverType(model.SolverTypeNaive), &model.NewSolverParameters{ PopulationSize: &populationSize }, model.SolverTypeNaive },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			mutationResolverContext := newMutationResolverContext()
+			input := newJobWithOneClause()
+			input.Solver = tc.solver
+			input.Parameters = tc.parameters
+			job, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), input)
+			if err != nil {
+				t.Fatalf("returned an error: %v", err)
+			}
+			if job.Solver != tc.want {
+				t.Errorf("wrong Solver value: got '%s' want '%s'", job.Solver, tc.want)
+			}
+			if (job.Parameters == nil) != (tc.parameters == nil) {
+				t.Fatalf("nil expectations violated for Parameters: got '%t' want '%t'", job.Parameters == nil, tc.parameters == nil)
+			}
+			if tc.parameters != nil && *job.Parameters.PopulationSize != *tc.parameters.PopulationSize {
+				t.Errorf("wrong PopulationSize value: got %d want %d", *job.Parameters.PopulationSize, *tc.parameters.PopulationSize)
+			}
+		})
+	}
+}
+
+func TestCreateJobWhenGivenInvalidSolver(t *testing.T) {
+	populationSize := 1
+	cases := []struct {
+		desc string
+		solver *model.SolverType
+		parameters *model.NewSolverParameters
+		err string
+	}{
+		{ "error on unregistered solver", solverType(model.SolverTypeCdcl), nil, "no solver registered for CDCL" },
+		{ "error on invalid parameters", nil, &model.NewSolverParameters{ PopulationSize: &populationSize }, "populationSize must be at least 2, got 1" },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			mutationResolverContext := newMutationResolverContext()
+			input := newJobWithOneClause()
+			input.Solver = tc.solver
+			input.Parameters = tc.parameters
+			job, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), input)
+			if job != nil {
+				t.Fatalf("got a job when should be error")
+			}
+			if err.Error() != tc.err {
+				t.Errorf("got '%v' want '%v'", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestCreateJobRecordsErrorWhenSolverFails(t *testing.T) {
+	cases := []struct {
+		desc string
+		input model.NewJob
+		want model.SolutionStatus
+	}{
+		{ "panicking solver yields error solution", newJobWithOneClause(), model.SolutionStatusError },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			mutationResolverContext := newMutationResolverContext()
+			mutationResolverContext.registry.Register(model.SolverTypeNaive, func(parameters *model.SolverParameters) solvers.Solver {
+				return &panickingSolver{}
+			})
+			job, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), tc.input)
+			if err != nil {
+				t.Fatalf("returned an error: %v", err)
+			}
+			solution := waitForSolution(t, mutationResolverContext, job.Uuid.String())
+			if solution.Status != tc.want {
+				t.Errorf("wrong Status value: got '%s' want '%s'", solution.Status, tc.want)
+			}
+		})
+	}
+}
+
+// failingJobRepository can't store any job.
+type failingJobRepository struct {
+	repositories.InMemoryJobRepository
+}
+
+func (r *failingJobRepository) InsertJob(job *model.Job) error {
+	return errors.New("disk full")
+}
+
+func TestCreateJobWhenJobCannotBeStored(t *testing.T) {
+	// arrange
+	solutionFactory := &factories.SolutionFactory{}
+	registry := solvers.NewRegistry()
+	registry.Register(model.SolverTypeNaive, func(parameters *model.SolverParameters) solvers.Solver {
+		return solvers.NewNaiveSolver(solutionFactory)
+	})
+	sut := NewJobDispatcher(registry, &failingJobRepository{}, &repositories.InMemorySolutionRepository{},
+		&factories.JobFactory{DefaultSolver: model.SolverTypeNaive}, solutionFactory, 0)
+
+	newJob := newJobWithOneClause()
+
+	// act
+	job, err := sut.DispatchJob(&newJob)
+
+	// assert
+	if err == nil || err.Error() != "disk full" {
+		t.Fatalf("wrong error: got '%v' want 'disk full'", err)
+	}
+	if job != nil {
+		t.Errorf("returned a job that wasn't stored: %v", job)
+	}
+	if len(sut.queue) != 0 {
+		t.Errorf("queued a job that wasn't stored: got %d queued jobs", len(sut.queue))
+	}
+}
+
+func TestCreateJobQueuesJobsBeyondWorkers(t *testing.T) {
+	cases := []struct {
+		desc string
+		jobs int
+		wantStates []model.JobState
+		wantPositions []int
+	}{
+		{ "single worker runs first job and queues the rest", 3,
+			[]model.JobState{ model.JobStateRunning, model.JobStateQueued, model.JobStateQueued },
+			[]int{ 0, 1, 2 },
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			mutationResolverContext := newMutationResolverContext()
+			solver := &blockingSolver{release: make(chan bool)}
+			mutationResolverContext.registry.Register(model.SolverTypeNaive, func(parameters *model.SolverParameters) solvers.Solver {
+				return solver
+			})
+			jobs := []*model.Job{}
+			for i := 0; i < tc.jobs; i++ {
+				job, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), newJobWithOneClause())
+				if err != nil {
+					t.Fatalf("returned an error: %v", err)
+				}
+				jobs = append(jobs, job)
+			}
+			waitForState(t, mutationResolverContext, jobs[0].Uuid.String(), model.JobStateRunning)
+			for i, job := range jobs {
+				assertJobStateIsEqual(t, mutationResolverContext, job, tc.wantStates[i], tc.wantPositions[i])
+			}
+			close(solver.release)
+			for _, job := range jobs {
+				waitForSolution(t, mutationResolverContext, job.Uuid.String())
+				waitForState(t, mutationResolverContext, job.Uuid.String(), model.JobStateDone)
+				assertJobStateIsEqual(t, mutationResolverContext, job, model.JobStateDone, 0)
+			}
+		})
+	}
+}
+
+func TestCreateJobWithLiterals(t *testing.T) {
+	// arrange
+	mutationResolverContext := newMutationResolverContext()
+	input := model.NewJob{
+		Name: "literals",
+		Clauses: []*model.NewClause{
+			{ Literals: []*model.NewVariable{ { Name: "v1", Negated: true } } },
+			{ Literals: []*model.NewVariable{ { Name: "v1", Negated: false }, { Name: "v2", Negated: false }, { Name: "v3", Negated: true }, { Name: "v4", Negated: false } } },
+		},
+	}
+	want := &model.Job{
+		Name: "literals",
+		Clauses: []*model.Clause{
+			{ Literals: []*model.Variable{ { Name: "v1", Negated: true } } },
+			{ Literals: []*model.Variable{ { Name: "v1", Negated: false }, { Name: "v2", Negated: false }, { Name: "v3", Negated: true }, { Name: "v4", Negated: false } } },
+		},
+	}
+
+	// act
+	got, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), input)
+
+	// assert
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	done := waitForState(t, mutationResolverContext, got.Uuid.String(), model.JobStateDone)
+	want.Done = true
+	assertJobsAreEqual(t, done, want)
+	if got.Clauses[0].Var1() != got.Clauses[0].Literals[0] || got.Clauses[0].Var2() != nil || got.Clauses[0].Var3() != nil {
+		t.Errorf("wrong deprecated fields for unit clause: got (%v %v %v)", got.Clauses[0].Var1(), got.Clauses[0].Var2(), got.Clauses[0].Var3())
+	}
+}
+
+func TestCreateJobWhenGivenInvalidClauses(t *testing.T) {
+	variable := &model.NewVariable{ Name: "v1", Negated: false }
+	cases := []struct {
+		desc string
+		clause *model.NewClause
+		want string
+	}{
+		{ "error on null clause", nil, "clause 1: clause is null" },
+		{ "error on missing fields", &model.NewClause{ Var1: variable, Var2: variable }, "clause 1: var1, var2 and var3 are required when literals is not given" },
+		{ "error on both forms", &model.NewClause{ Literals: []*model.NewVariable{ variable }, Var1: variable }, "clause 1: give either literals or var1, var2 and var3, not both" },
+		{ "error on empty literals", &model.NewClause{ Literals: []*model.NewVariable{} }, "clause 1: clause has no literals" },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			mutationResolverContext := newMutationResolverContext()
+			input := newJobWithOneClause()
+			input.Clauses = append(input.Clauses, tc.clause)
+
+			// act
+			_, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), input)
+
+			// assert
+			if err == nil {
+				t.Fatalf("failed to return error")
+			}
+			if err.Error() != tc.want {
+				t.Errorf("wrong error: got '%s' want '%s'", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+func TestCreateJobFromDimacs(t *testing.T) {
+	// arrange
+	mutationResolverContext := newMutationResolverContext()
+	cnf := "c example\np cnf 4 2\n1 -2 3 4 0\n-1 0\n"
+	want := &model.Job{
+		Name: "dimacs",
+		Clauses: []*model.Clause{
+			{
+				Literals: []*model.Variable{
+					{ Name: "x1", Negated: false },
+					{ Name: "x2", Negated: true },
+					{ Name: "x3", Negated: false },
+					{ Name: "x4", Negated: false },
+				},
+			},
+			{
+				Literals: []*model.Variable{
+					{ Name: "x1", Negated: true },
+				},
+			},
+		},
+	}
+
+	// act
+	got, err := mutationResolverContext.mutationResolver.CreateJobFromDimacs(context.TODO(), "dimacs", cnf, solverType(model.SolverTypeNaive), nil)
+
+	// assert
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	done := waitForState(t, mutationResolverContext, got.Uuid.String(), model.JobStateDone)
+	want.Done = true
+	assertJobsAreEqual(t, done, want)
+}
+
+func TestCreateJobFromDimacsWhenGivenInvalidInput(t *testing.T) {
+	cases := []struct {
+		desc string
+		cnf string
+		want string
+	}{
+		{ "error on parse failure", "p cnf 3 1\n1 2 4 0\n", "line 2: literal 4 exceeds the 3 declared variables" },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			mutationResolverContext := newMutationResolverContext()
+
+			// act
+			_, err := mutationResolverContext.mutationResolver.CreateJobFromDimacs(context.TODO(), "dimacs", tc.cnf, nil, nil)
+
+			// assert
+			if err == nil {
+				t.Fatalf("failed to return error")
+			}
+			if err.Error() != tc.want {
+				t.Errorf("wrong error: got '%s' want '%s'", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+func TestCancelJob(t *testing.T) {
+	cases := []struct {
+		desc string
+		index int
+		wantPositions []int
+	}{
+		{ "cancelling the running job starts the next one", 0, []int{ 0, 0, 1 } },
+		{ "cancelling a queued job removes it from the queue", 1, []int{ 0, 0, 1 } },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			mutationResolverContext := newMutationResolverContext()
+			solver := &blockingSolver{release: make(chan bool)}
+			defer close(solver.release)
+			mutationResolverContext.registry.Register(model.SolverTypeNaive, func(parameters *model.SolverParameters) solvers.Solver {
+				return solver
+			})
+			jobs := []*model.Job{}
+			for i := 0; i < 3; i++ {
+				job, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), newJobWithOneClause())
+				if err != nil {
+					t.Fatalf("returned an error: %v", err)
+				}
+				jobs = append(jobs, job)
+			}
+			waitForState(t, mutationResolverContext, jobs[0].Uuid.String(), model.JobStateRunning)
+
+			// act
+			got, err := mutationResolverContext.mutationResolver.CancelJob(context.TODO(), jobs[tc.index].Uuid.String())
+
+			// assert
+			if err != nil {
+				t.Fatalf("returned an error: %v", err)
+			}
+			if got.State != model.JobStateCancelled || !got.Done {
+				t.Errorf("wrong returned job: got (%s %t) want (%s %t)", got.State, got.Done, model.JobStateCancelled, true)
+			}
+			solution := waitForSolution(t, mutationResolverContext, jobs[tc.index].Uuid.String())
+			if solution.Status != model.SolutionStatusCancelled {
+				t.Errorf("wrong Status value: got '%s' want '%s'", solution.Status, model.SolutionStatusCancelled)
+			}
+			waitForState(t, mutationResolverContext, jobs[tc.index].Uuid.String(), model.JobStateCancelled)
+			cancelled, _ := mutationResolverContext.queryResolver.Job(context.TODO(), jobs[tc.index].Uuid.String())
+			if !cancelled.Done {
+				t.Errorf("cancelled job is not done")
+			}
+			for i, job := range jobs {
+				if i == tc.index {
+					continue
+				}
+				if tc.index == 0 && i == 1 {
+					waitForState(t, mutationResolverContext, job.Uuid.String(), model.JobStateRunning)
+				}
+				position, _ := mutationResolverContext.jobResolver.QueuePosition(context.TODO(), job)
+				if (position == nil) != (tc.wantPositions[i] == 0) || (position != nil && *position != tc.wantPositions[i]) {
+					t.Errorf("wrong QueuePosition value for job %d: got %v want %d", i, position, tc.wantPositions[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCancelJobWhenNotQueuedOrRunning(t *testing.T) {
+	cases := []struct {
+		desc string
+		uuid func(testing.TB, *mutationResolverContext) string
+		want string
+	}{
+		{ "error on invalid uuid", func(testing.TB, *mutationResolverContext) string { return "invalid" }, "invalid UUID length: 7" },
+		{ "error on unknown job", func(testing.TB, *mutationResolverContext) string { return uuidOfJobWithKnownUuid() }, "unable to find job with uuid " + uuidOfJobWithKnownUuid() },
+		{ "error on finished job", func(t testing.TB, mutationResolverContext *mutationResolverContext) string {
+			job, _ := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), newJobWithOneClause())
+			waitForState(t, mutationResolverContext, job.Uuid.String(), model.JobStateDone)
+			return job.Uuid.String()
+		}, "is not queued or running" },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			mutationResolverContext := newMutationResolverContext()
+			uuid := tc.uuid(t, mutationResolverContext)
+
+			// act
+			_, err := mutationResolverContext.mutationResolver.CancelJob(context.TODO(), uuid)
+
+			// assert
+			if err == nil {
+				t.Fatalf("failed to return error")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("wrong error: got '%s' want '%s'", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+func TestRecoverJobs(t *testing.T) {
+	cases := []struct {
+		desc string
+		solver model.SolverType
+		state model.JobState
+		want model.SolutionStatus
+	}{
+		{ "running job is solved again", model.SolverTypeNaive, model.JobStateRunning, model.SolutionStatusSatisfiable },
+		{ "queued job is solved", model.SolverTypeNaive, model.JobStateQueued, model.SolutionStatusSatisfiable },
+		{ "job with unregistered solver is finished with an error", model.SolverTypeCdcl, model.JobStateQueued, model.SolutionStatusError },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			mutationResolverContext := newMutationResolverContext()
+			job := jobWithOneClause()
+			job.Uuid = u.New()
+			job.Solver = tc.solver
+			job.State = tc.state
+			mutationResolverContext.jobRepository.InsertJob(job)
+
+			// act
+			err := mutationResolverContext.jobDispatcher.RecoverJobs()
+
+			// assert
+			if err != nil {
+				t.Fatalf("returned an error: %v", err)
+			}
+			solution := waitForSolution(t, mutationResolverContext, job.Uuid.String())
+			if solution.Status != tc.want {
+				t.Errorf("wrong Status value: got '%s' want '%s'", solution.Status, tc.want)
+			}
+			waitForState(t, mutationResolverContext, job.Uuid.String(), model.JobStateDone)
+		})
+	}
+}
+
+func TestJobsPagesThroughJobs(t *testing.T) {
+	// arrange
+	mutationResolverContext := newMutationResolverContext()
+	want := []string{}
+	for i := 0; i < 5; i++ {
+		input := newJobWithOneClause()
+		input.Name = fmt.Sprintf("job %d", i)
+		job, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), input)
+		if err != nil {
+			t.Fatalf("returned an error: %v", err)
+		}
+		want = append(want, job.Uuid.String())
+	}
+	first := 2
+	order := &model.JobOrder{Field: model.JobOrderFieldName, Direction: model.OrderDirectionAsc}
+	got := []string{}
+	var after *string
+
+	// act
+	for page := 0; page < 5; page++ {
+		connection, err := mutationResolverContext.queryResolver.Jobs(context.TODO(), &first, after, nil, order)
+		if err != nil {
+			t.Fatalf("returned an error: %v", err)
+		}
+		if connection.TotalCount != 5 {
+			t.Errorf("wrong TotalCount value: got %d want %d", connection.TotalCount, 5)
+		}
+		for _, edge := range connection.Edges {
+			got = append(got, edge.Node.Uuid.String())
+		}
+		if !connection.PageInfo.HasNextPage {
+			break
+		}
+		after = connection.PageInfo.EndCursor
+	}
+
+	// assert
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("wrong jobs: got %v want %v", got, want)
+	}
+}
+
+func TestJobsWhenGivenInvalidInput(t *testing.T) {
+	tooMany := 101
+	negative := -1
+	invalid := "stuff"
+	unknown := encodeCursor(u.MustParse(uuidOfJobWithKnownUuid()))
+	cases := []struct {
+		desc string
+		first *int
+		after *string
+		want string
+	}{
+		{ "error on page too large", &tooMany, nil, "first must be between 0 and 100, got 101" },
+		{ "error on negative page size", &negative, nil, "first must be between 0 and 100, got -1" },
+		{ "error on invalid cursor", nil, &invalid, "invalid cursor \"stuff\"" },
+		{ "error on cursor of unknown job", nil, &unknown, "unable to find job with uuid " + uuidOfJobWithKnownUuid() },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			mutationResolverContext := newMutationResolverContext()
+
+			// act
+			_, err := mutationResolverContext.queryResolver.Jobs(context.TODO(), tc.first, tc.after, nil, nil)
+
+			// assert
+			if err == nil {
+				t.Fatalf("failed to return error")
+			}
+			if err.Error() != tc.want {
+				t.Errorf("wrong error: got '%s' want '%s'", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+func TestJobSolution(t *testing.T) {
+	// arrange
+	mutationResolverContext := newMutationResolverContext()
+	solver := &blockingSolver{release: make(chan bool)}
+	mutationResolverContext.registry.Register(model.SolverTypeNaive, func(parameters *model.SolverParameters) solvers.Solver {
+		return solver
+	})
+	job, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), newJobWithOneClause())
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	waitForState(t, mutationResolverContext, job.Uuid.String(), model.JobStateRunning)
+
+	// act
+	pending, pendingErr := mutationResolverContext.jobResolver.Solution(context.TODO(), job)
+	close(solver.release)
+	waitForState(t, mutationResolverContext, job.Uuid.String(), model.JobStateDone)
+	finished, finishedErr := mutationResolverContext.jobResolver.Solution(context.TODO(), job)
+
+	// assert
+	if pendingErr != nil || pending != nil {
+		t.Errorf("wrong pending solution: got (%v, %v) want (nil, nil)", pending, pendingErr)
+	}
+	if finishedErr != nil {
+		t.Fatalf("returned an error: %v", finishedErr)
+	}
+	if finished == nil || finished.Uuid != job.Uuid {
+		t.Fatalf("failed to return solution of job %s: got %v", job.Uuid.String(), finished)
+	}
+	got, err := mutationResolverContext.solutionResolver.Job(context.TODO(), finished)
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	if got == nil || got.Uuid != job.Uuid {
+		t.Errorf("wrong job for solution: got %v want %v", got, job)
+	}
+}
+
+func TestJobProgress(t *testing.T) {
+	// arrange
+	mutationResolverContext := newMutationResolverContext()
+	solver := &blockingSolver{release: make(chan bool)}
+	mutationResolverContext.registry.Register(model.SolverTypeNaive, func(parameters *model.SolverParameters) solvers.Solver {
+		return solver
+	})
+	running, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), newJobWithOneClause())
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	queued, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), newJobWithOneClause())
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	waitForState(t, mutationResolverContext, running.Uuid.String(), model.JobStateRunning)
+
+	// act
+	updates, err := mutationResolverContext.subscriptionResolver.JobProgress(context.TODO(), queued.Uuid.String())
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	first := <-updates
+	close(solver.release)
+	got := append([]*model.JobProgress{first}, receiveProgress(t, updates)...)
+
+	// assert
+	if first.State != model.JobStateQueued {
+		t.Errorf("wrong state of first update: got %s want %s", first.State, model.JobStateQueued)
+	}
+	last := got[len(got) - 1]
+	if last.State != model.JobStateDone {
+		t.Errorf("wrong state of last update: got %s want %s", last.State, model.JobStateDone)
+	}
+	if last.Solution == nil || last.Solution.Uuid != queued.Uuid {
+		t.Fatalf("failed to end with solution of job %s: got %v", queued.Uuid.String(), last.Solution)
+	}
+	for index, update := range got {
+		if update.Uuid != queued.Uuid {
+			t.Errorf("wrong uuid of update %d: got %s want %s", index, update.Uuid.String(), queued.Uuid.String())
+		}
+		if index < len(got) - 1 && update.Solution != nil {
+			t.Errorf("update %d carries a solution before the last one", index)
+		}
+	}
+}
+
+func TestJobProgressWhenFinished(t *testing.T) {
+	// arrange
+	mutationResolverContext := newMutationResolverContext()
+	job, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), newJobWithOneClause())
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	waitForState(t, mutationResolverContext, job.Uuid.String(), model.JobStateDone)
+
+	// act
+	updates, err := mutationResolverContext.subscriptionResolver.JobProgress(context.TODO(), job.Uuid.String())
+
+	// assert
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	got := receiveProgress(t, updates)
+	if len(got) != 1 {
+		t.Fatalf("wrong number of updates: got %d want 1", len(got))
+	}
+	if got[0].State != model.JobStateDone || got[0].Solution == nil {
+		t.Errorf("failed to send finished progress: got state %s and solution %v", got[0].State, got[0].Solution)
+	}
+}
+
+func TestJobProgressEndsWithContext(t *testing.T) {
+	// arrange
+	mutationResolverContext := newMutationResolverContext()
+	solver := &blockingSolver{release: make(chan bool)}
+	defer close(solver.release)
+	mutationResolverContext.registry.Register(model.SolverTypeNaive, func(parameters *model.SolverParameters) solvers.Solver {
+		return solver
+	})
+	job, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), newJobWithOneClause())
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	waitForState(t, mutationResolverContext, job.Uuid.String(), model.JobStateRunning)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// act
+	updates, err := mutationResolverContext.subscriptionResolver.JobProgress(ctx, job.Uuid.String())
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	cancel()
+
+	// assert
+	got := receiveProgress(t, updates)
+	if len(got) > 1 {
+		t.Errorf("received updates after the context was done: got %d want at most 1", len(got))
+	}
+}
+
+func TestJobProgressWhenGivenInvalidInput(t *testing.T) {
+	cases := []struct {
+		desc string
+		uuid string
+		err string
+	}{
+		{ "error on job not found", "b2312d3c-b09d-4d35-9528-a70104c70738", "unable to find job with uuid b2312d3c-b09d-4d35-9528-a70104c70738" },
+		{ "error on invalid uuid", "stuff", "invalid UUID length: 5" },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			mutationResolverContext := newMutationResolverContext()
+			updates, err := mutationResolverContext.subscriptionResolver.JobProgress(context.TODO(), tc.uuid)
+			if updates != nil {
+				t.Fatalf("got updates when should be error")
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("got '%v' want '%v'", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestJobCurrentBest(t *testing.T) {
+	// arrange
+	mutationResolverContext := newMutationResolverContext()
+	mutationResolverContext.registry.Register(model.SolverTypeWalksat, func(parameters *model.SolverParameters) solvers.Solver {
+		return solvers.NewWalkSatSolver(1 << 30, 1000, 0.5, time.Minute, mutationResolverContext.solutionFactory, &factories.SeededRandomFactory{Seed: 1})
+	})
+	input := model.NewJob{
+		Name: "unsatisfiable",
+		Clauses: []*model.NewClause{
+			{ Literals: []*model.NewVariable{ { Name: "v1", Negated: false } } },
+			{ Literals: []*model.NewVariable{ { Name: "v1", Negated: true } } },
+		},
+		Solver: solverType(model.SolverTypeWalksat),
+	}
+	job, err := mutationResolverContext.mutationResolver.CreateJob(context.TODO(), input)
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+
+	// act
+	incumbent := waitForCurrentBest(t, mutationResolverContext, job)
+	saved, savedErr := mutationResolverContext.solutionRepository.FindIncumbent(job.Uuid)
+	_, err = mutationResolverContext.mutationResolver.CancelJob(context.TODO(), job.Uuid.String())
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	waitForState(t, mutationResolverContext, job.Uuid.String(), model.JobStateCancelled)
+	final, finalErr := mutationResolverContext.jobResolver.CurrentBest(context.TODO(), job)
+
+	// assert
+	if incumbent.Status != model.SolutionStatusUnknown || incumbent.Score != 0.5 || len(incumbent.Variables) != 1 {
+		t.Errorf("wrong incumbent: got status %s, score %f and %d variables", incumbent.Status, incumbent.Score, len(incumbent.Variables))
+	}
+	if savedErr != nil || saved.Score != incumbent.Score {
+		t.Errorf("failed to save the incumbent: got (%v, %v)", saved, savedErr)
+	}
+	if finalErr != nil {
+		t.Fatalf("returned an error: %v", finalErr)
+	}
+	if final == nil || final.Status != model.SolutionStatusCancelled {
+		t.Errorf("failed to return the solution of the finished job: got %v", final)
+	}
+	_, err = mutationResolverContext.solutionRepository.FindIncumbent(job.Uuid)
+	if !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("failed to drop the saved incumbent: got %v", err)
+	}
+}
+
+func TestJobCurrentBestWhenSaved(t *testing.T) {
+	// arrange
+	mutationResolverContext := newMutationResolverContext()
+	job := jobWithKnownUuid()
+	job.State = model.JobStateRunning
+	mutationResolverContext.jobRepository.InsertJob(job)
+	want := solutionWithKnownUuid()
+	mutationResolverContext.solutionRepository.SaveIncumbent(want)
+
+	// act
+	got, err := mutationResolverContext.jobResolver.CurrentBest(context.TODO(), job)
+
+	// assert
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	assertSolutionsAreEqual(t, got, want)
+}
+
 func TestJobWhenGivenInvalidInput(t *testing.T) {
 	cases := []struct {
 		desc string
@@ -172,6 +927,114 @@ func TestSolutionWhenGivenValidInput(t *testing.T) {
 	}
 }
 
+type panickingSolver struct {
+}
+
+func (s *panickingSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
+	panic("solver failure")
+}
+
+type blockingSolver struct {
+	release chan bool
+}
+
+func (s *blockingSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
+	status := model.SolutionStatusUnknown
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		status = model.SolutionStatusCancelled
+	}
+	return &model.Solution{
+		Uuid: job.Uuid,
+		Variables: []*model.SolvedVariable{},
+		Status: status,
+	}
+}
+
+func waitForState(t testing.TB, mutationResolverContext *mutationResolverContext, uuid string, state model.JobState) *model.Job {
+	start := time.Now()
+	for time.Since(start) < 5 * time.Second {
+		job, err := mutationResolverContext.queryResolver.Job(context.TODO(), uuid)
+		if err == nil && job.State == state {
+			return job
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for job %s to be %s", uuid, state)
+	return nil
+}
+
+func assertJobStateIsEqual(t testing.TB, mutationResolverContext *mutationResolverContext, job *model.Job, state model.JobState, position int) {
+	job, err := mutationResolverContext.queryResolver.Job(context.TODO(), job.Uuid.String())
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	if job.State != state {
+		t.Errorf("wrong State value for job %s: got '%s' want '%s'", job.Uuid.String(), job.State, state)
+	}
+	got, err := mutationResolverContext.jobResolver.QueuePosition(context.TODO(), job)
+	if err != nil {
+		t.Fatalf("returned an error: %v", err)
+	}
+	if (got == nil) != (position == 0) {
+		t.Fatalf("nil expectations violated for QueuePosition: got '%t' want '%t'", got == nil, position == 0)
+	}
+	if got != nil && *got != position {
+		t.Errorf("wrong QueuePosition value: got %d want %d", *got, position)
+	}
+}
+
+func waitForSolution(t testing.TB, mutationResolverContext *mutationResolverContext, uuid string) *model.Solution {
+	start := time.Now()
+	for time.Since(start) < 5 * time.Second {
+		solution, err := mutationResolverContext.queryResolver.Solution(context.TODO(), uuid)
+		if err == nil {
+			return solution
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for solution %s", uuid)
+	return nil
+}
+
+func waitForCurrentBest(t testing.TB, mutationResolverContext *mutationResolverContext, job *model.Job) *model.Solution {
+	start := time.Now()
+	for time.Since(start) < 5 * time.Second {
+		solution, err := mutationResolverContext.jobResolver.CurrentBest(context.TODO(), job)
+		if err != nil {
+			t.Fatalf("returned an error: %v", err)
+		}
+		if solution != nil {
+			return solution
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for an incumbent of job %s", job.Uuid.String())
+	return nil
+}
+
+func receiveProgress(t testing.TB, updates <-chan *model.JobProgress) []*model.JobProgress {
+	received := []*model.JobProgress{}
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return received
+			}
+			received = append(received, update)
+		case <-timeout:
+			t.Fatalf("timed out waiting for progress updates to end")
+			return nil
+		}
+	}
+}
+
+func solverType(solverType model.SolverType) *model.SolverType {
+	return &solverType
+}
+
 func newJobWithOneClause() model.NewJob {
 	return model.NewJob{
 		Clauses: []*model.NewClause{
@@ -188,9 +1051,11 @@ func jobWithOneClause() *model.Job {
 	return &model.Job{
 		Clauses: []*model.Clause{
 			{
-				Var1: &model.Variable{ Name: "v1", Negated: true },
-				Var2: &model.Variable{ Name: "v2", Negated: false },
-				Var3: &model.Variable{ Name: "v3", Negated: true },
+				Literals: []*model.Variable{
+					{ Name: "v1", Negated: true },
+					{ Name: "v2", Negated: false },
+					{ Name: "v3", Negated: true },
+				},
 			},
 		},
 	}
@@ -206,9 +1071,11 @@ func jobWithKnownUuid() *model.Job {
 		Uuid: uuid,
 		Clauses: []*model.Clause{
 			{
-				Var1: &model.Variable{ Name: "v1", Negated: true },
-				Var2: &model.Variable{ Name: "v2", Negated: false },
-				Var3: &model.Variable{ Name: "v3", Negated: true },
+				Literals: []*model.Variable{
+					{ Name: "v1", Negated: true },
+					{ Name: "v2", Negated: false },
+					{ Name: "v3", Negated: true },
+				},
 			},
 		},
 	}
@@ -247,9 +1114,12 @@ func assertJobsAreEqual(t testing.TB, got *model.Job, want *model.Job) {
 		t.Fatalf("wrong number of clauses: got '%d' want '%d'", len(got.Clauses), len(want.Clauses))
 	}
 	for i := range got.Clauses {
-		assertVariablesAreEqual(t, i, 1, got.Clauses[i].Var1, want.Clauses[i].Var1)
-		assertVariablesAreEqual(t, i, 2, got.Clauses[i].Var2, want.Clauses[i].Var2)
-		assertVariablesAreEqual(t, i, 3, got.Clauses[i].Var3, want.Clauses[i].Var3)
+		if len(got.Clauses[i].Literals) != len(want.Clauses[i].Literals) {
+			t.Fatalf("wrong number of literals in clause %d: got '%d' want '%d'", i, len(got.Clauses[i].Literals), len(want.Clauses[i].Literals))
+		}
+		for j := range got.Clauses[i].Literals {
+			assertVariablesAreEqual(t, i, j + 1, got.Clauses[i].Literals[j], want.Clauses[i].Literals[j])
+		}
 	}
 }
 
@@ -0,0 +1,39 @@
+package graph
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	u "github.com/google/uuid"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/dimacs"
+)
+
+const SolutionPath = "/solutions/"
+
+// SolutionHandler serves stored solutions in the SAT competition output format
+// at /solutions/<uuid> so they can be downloaded without a GraphQL client.
+type SolutionHandler struct {
+	JobDispatcher *JobDispatcher
+}
+
+func (h *SolutionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	uuid, err := u.Parse(strings.TrimPrefix(r.URL.Path, SolutionPath))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	solution, err := h.JobDispatcher.FindSolution(uuid)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.sol\"", uuid.String()))
+	dimacs.WriteSolution(w, solution)
+}
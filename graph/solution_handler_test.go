@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestSolutionHandler(t *testing.T) {
+	cases := []struct {
+		desc string
+		method string
+		path string
+		wantCode int
+		wantBody string
+	}{
+		{ "stored solution is rendered", http.MethodGet, SolutionPath + uuidOfSolutionWithKnownUuid(), http.StatusOK,
+			"c solution " + uuidOfSolutionWithKnownUuid() + "\nc status SATISFIABLE\ns SATISFIABLE\nv -1 -2 -3\nv 0\n" },
+		{ "error on invalid uuid", http.MethodGet, SolutionPath + "stuff", http.StatusBadRequest, "invalid UUID length: 5\n" },
+		{ "error on unknown solution", http.MethodGet, SolutionPath + "b2312d3c-b09d-4d35-9528-a70104c70738", http.StatusNotFound,
+			"unable to find solution with uuid b2312d3c-b09d-4d35-9528-a70104c70738\n" },
+		{ "error on other methods", http.MethodPost, SolutionPath + uuidOfSolutionWithKnownUuid(), http.StatusMethodNotAllowed, "method not allowed\n" },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			mutationResolverContext := newMutationResolverContext()
+			solution := solutionWithKnownUuid()
+			solution.Status = model.SolutionStatusSatisfiable
+			mutationResolverContext.solutionRepository.InsertSolution(solution)
+			sut := &SolutionHandler{JobDispatcher: mutationResolverContext.jobDispatcher}
+			recorder := httptest.NewRecorder()
+
+			// act
+			sut.ServeHTTP(recorder, httptest.NewRequest(tc.method, tc.path, nil))
+
+			// assert
+			if recorder.Code != tc.wantCode {
+				t.Errorf("wrong status code: got %d want %d", recorder.Code, tc.wantCode)
+			}
+			if recorder.Body.String() != tc.wantBody {
+				t.Errorf("wrong body: got %q want %q", recorder.Body.String(), tc.wantBody)
+			}
+			if tc.wantCode == http.StatusOK && !strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain") {
+				t.Errorf("wrong Content-Type: got '%s'", recorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
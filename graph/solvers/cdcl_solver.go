@@ -0,0 +1,560 @@
+package solvers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+const (
+	cdclRestartBase = 100
+	cdclVariableDecay = 0.95
+	cdclClauseDecay = 0.999
+	cdclRescaleLimit = 1e100
+)
+
+type cdclResult int
+
+const (
+	cdclUndecided cdclResult = iota
+	cdclSatisfiable
+	cdclUnsatisfiable
+	cdclInterrupted
+)
+
+func (r cdclResult) status(ctx context.Context) model.SolutionStatus {
+	switch r {
+	case cdclSatisfiable:
+		return model.SolutionStatusSatisfiable
+	case cdclUnsatisfiable:
+		return model.SolutionStatusUnsatisfiable
+	case cdclInterrupted:
+		return interruptedStatus(ctx)
+	}
+	return model.SolutionStatusUnknown
+}
+
+type cdclSolver struct {
+	maxTime time.Duration
+	solutionFactory *factories.SolutionFactory
+}
+
+func NewCdclSolver(
+	maxTime time.Duration,
+	solutionFactory *factories.SolutionFactory,
+) *cdclSolver {
+	return &cdclSolver{
+		maxTime: maxTime,
+		solutionFactory: solutionFactory,
+	}
+}
+
+func (s *cdclSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, s.maxTime)
+	defer cancel()
+	state := newCdclState(ctx, job)
+	// report once up front, since a job may take a while to reach
+	// progressInterval conflicts
+	state.reporter.Report(Progress{})
+	status := state.solve().status(ctx)
+	return s.solutionFactory.ConstructSolution(state.variables(), job, state.conflicts, time.Since(start), status)
+}
+
+type cdclClause struct {
+	literals []int
+	learnt bool
+	deleted bool
+	activity float64
+}
+
+// Literals are encoded as variable<<1 for a variable and variable<<1|1 for its
+// negation, so watches can be indexed directly and negation is literal^1.
+type cdclState struct {
+	names []string
+	clauses []*cdclClause
+	learnts []*cdclClause
+	watches [][]*cdclClause
+	values []int8
+	levels []int
+	reasons []*cdclClause
+	polarities []int
+	seen []bool
+	trail []int
+	trailLimits []int
+	propagated int
+	activities []float64
+	variableIncrement float64
+	clauseIncrement float64
+	heap []int
+	heapIndices []int
+	maxLearnts float64
+	conflicts int
+	decisions int
+	ctx context.Context
+	reporter ProgressReporter
+	unsatisfiable bool
+}
+
+func newCdclState(ctx context.Context, job *model.Job) *cdclState {
+	formula := job.Formula()
+	names := formula.Names
+	s := &cdclState{
+		names: names,
+		clauses: []*cdclClause{},
+		learnts: []*cdclClause{},
+		watches: make([][]*cdclClause, 2 * len(names)),
+		values: make([]int8, len(names)),
+		levels: make([]int, len(names)),
+		reasons: make([]*cdclClause, len(names)),
+		polarities: make([]int, len(names)),
+		seen: make([]bool, len(names)),
+		trail: []int{},
+		trailLimits: []int{},
+		activities: make([]float64, len(names)),
+		variableIncrement: 1.0,
+		clauseIncrement: 1.0,
+		heap: []int{},
+		heapIndices: make([]int, len(names)),
+		ctx: ctx,
+		reporter: progressReporter(ctx),
+	}
+	for variable := range names {
+		s.polarities[variable] = 1
+		s.heapIndices[variable] = -1
+		s.heapInsert(variable)
+	}
+	for _, literals := range formula.Clauses {
+		s.addClause(literals)
+	}
+	s.maxLearnts = float64(len(s.clauses)) / 3.0
+	if s.maxLearnts < 100 {
+		s.maxLearnts = 100
+	}
+	return s
+}
+
+func (s *cdclState) addClause(dpllLiterals []int) {
+	literals := make([]int, len(dpllLiterals))
+	for index, literal := range dpllLiterals {
+		variable, value := decodeLiteral(literal)
+		literals[index] = variable << 1
+		if value < 0 {
+			literals[index] |= 1
+		}
+	}
+	if len(literals) == 0 {
+		s.unsatisfiable = true
+		return
+	}
+	if len(literals) == 1 {
+		switch s.literalValue(literals[0]) {
+		case -1:
+			s.unsatisfiable = true
+		case 0:
+			s.enqueue(literals[0], nil)
+		}
+		return
+	}
+	clause := &cdclClause{literals: literals}
+	s.clauses = append(s.clauses, clause)
+	s.watch(clause)
+}
+
+func (s *cdclState) solve() cdclResult {
+	if s.unsatisfiable || s.propagate() != nil {
+		return cdclUnsatisfiable
+	}
+	for restart := 0; ; restart++ {
+		result := s.search(cdclRestartBase * luby(restart))
+		if result != cdclUndecided {
+			return result
+		}
+	}
+}
+
+// search runs until the formula is decided, the context is done or the
+// conflict budget for the current restart is spent.
+func (s *cdclState) search(budget int) cdclResult {
+	conflicts := 0
+	for {
+		conflict := s.propagate()
+		if conflict != nil {
+			s.conflicts++
+			conflicts++
+			if s.conflicts % progressInterval == 0 {
+				s.reporter.Report(Progress{Cycles: s.conflicts})
+			}
+			if s.decisionLevel() == 0 {
+				return cdclUnsatisfiable
+			}
+			learnt, level := s.analyze(conflict)
+			s.backtrack(level)
+			if len(learnt) == 1 {
+				s.enqueue(learnt[0], nil)
+			} else {
+				clause := &cdclClause{literals: learnt, learnt: true}
+				s.learnts = append(s.learnts, clause)
+				s.watch(clause)
+				s.bumpClause(clause)
+				s.enqueue(learnt[0], clause)
+			}
+			s.variableIncrement /= cdclVariableDecay
+			s.clauseIncrement /= cdclClauseDecay
+			continue
+		}
+		if s.ctx.Err() != nil {
+			s.backtrack(0)
+			return cdclInterrupted
+		}
+		if conflicts >= budget {
+			s.backtrack(0)
+			return cdclUndecided
+		}
+		if float64(len(s.learnts) - len(s.trail)) >= s.maxLearnts {
+			s.reduceLearnts()
+		}
+		variable := s.pickBranchVariable()
+		if variable < 0 {
+			return cdclSatisfiable
+		}
+		s.decisions++
+		s.trailLimits = append(s.trailLimits, len(s.trail))
+		s.enqueue(variable << 1 | s.polarities[variable], nil)
+	}
+}
+
+// propagate performs unit propagation using two watched literals per clause
+// and returns the first conflicting clause, if any.
+func (s *cdclState) propagate() *cdclClause {
+	for s.propagated < len(s.trail) {
+		falseLiteral := s.trail[s.propagated] ^ 1
+		s.propagated++
+		watchers := s.watches[falseLiteral]
+		kept := watchers[:0]
+		var conflict *cdclClause
+		for index, clause := range watchers {
+			if clause.deleted {
+				continue
+			}
+			if conflict != nil {
+				kept = append(kept, watchers[index:]...)
+				break
+			}
+			literals := clause.literals
+			if literals[0] == falseLiteral {
+				literals[0], literals[1] = literals[1], literals[0]
+			}
+			if s.literalValue(literals[0]) == 1 {
+				kept = append(kept, clause)
+				continue
+			}
+			if s.findNewWatch(clause) {
+				continue
+			}
+			kept = append(kept, clause)
+			if s.literalValue(literals[0]) == -1 {
+				conflict = clause
+			} else {
+				s.enqueue(literals[0], clause)
+			}
+		}
+		s.watches[falseLiteral] = kept
+		if conflict != nil {
+			s.propagated = len(s.trail)
+			return conflict
+		}
+	}
+	return nil
+}
+
+func (s *cdclState) findNewWatch(clause *cdclClause) bool {
+	literals := clause.literals
+	for index := 2; index < len(literals); index++ {
+		if s.literalValue(literals[index]) != -1 {
+			literals[1], literals[index] = literals[index], literals[1]
+			s.watches[literals[1]] = append(s.watches[literals[1]], clause)
+			return true
+		}
+	}
+	return false
+}
+
+// analyze derives the first unique implication point clause for a conflict
+// and returns it, asserting literal first, with the level to backtrack to.
+func (s *cdclState) analyze(conflict *cdclClause) ([]int, int) {
+	learnt := []int{0}
+	pending := 0
+	literal := -1
+	index := len(s.trail) - 1
+	clause := conflict
+	for {
+		if clause.learnt {
+			s.bumpClause(clause)
+		}
+		start := 0
+		if literal >= 0 {
+			start = 1
+		}
+		for _, other := range clause.literals[start:] {
+			variable := other >> 1
+			if s.seen[variable] || s.levels[variable] == 0 {
+				continue
+			}
+			s.seen[variable] = true
+			s.bumpVariable(variable)
+			if s.levels[variable] >= s.decisionLevel() {
+				pending++
+			} else {
+				learnt = append(learnt, other)
+			}
+		}
+		for !s.seen[s.trail[index] >> 1] {
+			index--
+		}
+		literal = s.trail[index]
+		index--
+		clause = s.reasons[literal >> 1]
+		s.seen[literal >> 1] = false
+		pending--
+		if pending == 0 {
+			break
+		}
+	}
+	learnt[0] = literal ^ 1
+	minimized := s.minimize(learnt)
+	for _, other := range learnt[1:] {
+		s.seen[other >> 1] = false
+	}
+	learnt = minimized
+	level := 0
+	if len(learnt) > 1 {
+		highest := 1
+		for index := 2; index < len(learnt); index++ {
+			if s.levels[learnt[index] >> 1] > s.levels[learnt[highest] >> 1] {
+				highest = index
+			}
+		}
+		learnt[1], learnt[highest] = learnt[highest], learnt[1]
+		level = s.levels[learnt[1] >> 1]
+	}
+	return learnt, level
+}
+
+// minimize removes literals that are implied by the rest of the learnt clause,
+// i.e. whose reason only contains literals already marked as seen by analyze.
+func (s *cdclState) minimize(learnt []int) []int {
+	minimized := []int{learnt[0]}
+	for _, literal := range learnt[1:] {
+		if !s.redundant(literal) {
+			minimized = append(minimized, literal)
+		}
+	}
+	return minimized
+}
+
+func (s *cdclState) redundant(literal int) bool {
+	reason := s.reasons[literal >> 1]
+	if reason == nil {
+		return false
+	}
+	for _, other := range reason.literals[1:] {
+		variable := other >> 1
+		if !s.seen[variable] && s.levels[variable] > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// reduceLearnts drops the less active half of the learnt clauses, keeping
+// binary clauses and clauses that are currently the reason for an assignment.
+func (s *cdclState) reduceLearnts() {
+	sort.Slice(s.learnts, func(i, j int) bool {
+		return s.learnts[i].activity < s.learnts[j].activity
+	})
+	kept := []*cdclClause{}
+	limit := s.clauseIncrement / float64(len(s.learnts))
+	for index, clause := range s.learnts {
+		removable := len(clause.literals) > 2 && !s.locked(clause)
+		if removable && (index < len(s.learnts) / 2 || clause.activity < limit) {
+			clause.deleted = true
+		} else {
+			kept = append(kept, clause)
+		}
+	}
+	s.learnts = kept
+	s.maxLearnts *= 1.1
+}
+
+func (s *cdclState) locked(clause *cdclClause) bool {
+	first := clause.literals[0]
+	return s.reasons[first >> 1] == clause && s.literalValue(first) == 1
+}
+
+func (s *cdclState) watch(clause *cdclClause) {
+	s.watches[clause.literals[0]] = append(s.watches[clause.literals[0]], clause)
+	s.watches[clause.literals[1]] = append(s.watches[clause.literals[1]], clause)
+}
+
+func (s *cdclState) enqueue(literal int, reason *cdclClause) {
+	variable := literal >> 1
+	s.values[variable] = 1
+	if literal & 1 == 1 {
+		s.values[variable] = -1
+	}
+	s.levels[variable] = s.decisionLevel()
+	s.reasons[variable] = reason
+	s.trail = append(s.trail, literal)
+}
+
+func (s *cdclState) backtrack(level int) {
+	if s.decisionLevel() <= level {
+		return
+	}
+	limit := s.trailLimits[level]
+	for index := len(s.trail) - 1; index >= limit; index-- {
+		variable := s.trail[index] >> 1
+		s.polarities[variable] = s.trail[index] & 1
+		s.values[variable] = 0
+		s.reasons[variable] = nil
+		if s.heapIndices[variable] < 0 {
+			s.heapInsert(variable)
+		}
+	}
+	s.trail = s.trail[:limit]
+	s.trailLimits = s.trailLimits[:level]
+	s.propagated = limit
+}
+
+func (s *cdclState) decisionLevel() int {
+	return len(s.trailLimits)
+}
+
+func (s *cdclState) literalValue(literal int) int8 {
+	value := s.values[literal >> 1]
+	if literal & 1 == 1 {
+		return -value
+	}
+	return value
+}
+
+func (s *cdclState) pickBranchVariable() int {
+	for len(s.heap) > 0 {
+		variable := s.heapRemoveMax()
+		if s.values[variable] == 0 {
+			return variable
+		}
+	}
+	return -1
+}
+
+func (s *cdclState) bumpVariable(variable int) {
+	s.activities[variable] += s.variableIncrement
+	if s.activities[variable] > cdclRescaleLimit {
+		for index := range s.activities {
+			s.activities[index] /= cdclRescaleLimit
+		}
+		s.variableIncrement /= cdclRescaleLimit
+	}
+	if s.heapIndices[variable] >= 0 {
+		s.heapUp(s.heapIndices[variable])
+	}
+}
+
+func (s *cdclState) bumpClause(clause *cdclClause) {
+	clause.activity += s.clauseIncrement
+	if clause.activity > cdclRescaleLimit {
+		for _, learnt := range s.learnts {
+			learnt.activity /= cdclRescaleLimit
+		}
+		s.clauseIncrement /= cdclRescaleLimit
+	}
+}
+
+func (s *cdclState) heapInsert(variable int) {
+	s.heapIndices[variable] = len(s.heap)
+	s.heap = append(s.heap, variable)
+	s.heapUp(len(s.heap) - 1)
+}
+
+func (s *cdclState) heapRemoveMax() int {
+	top := s.heap[0]
+	last := s.heap[len(s.heap) - 1]
+	s.heap = s.heap[:len(s.heap) - 1]
+	s.heapIndices[top] = -1
+	if len(s.heap) > 0 {
+		s.heap[0] = last
+		s.heapIndices[last] = 0
+		s.heapDown(0)
+	}
+	return top
+}
+
+func (s *cdclState) heapUp(index int) {
+	variable := s.heap[index]
+	for index > 0 {
+		parent := (index - 1) / 2
+		if s.activities[s.heap[parent]] >= s.activities[variable] {
+			break
+		}
+		s.heap[index] = s.heap[parent]
+		s.heapIndices[s.heap[index]] = index
+		index = parent
+	}
+	s.heap[index] = variable
+	s.heapIndices[variable] = index
+}
+
+func (s *cdclState) heapDown(index int) {
+	variable := s.heap[index]
+	for {
+		child := 2 * index + 1
+		if child >= len(s.heap) {
+			break
+		}
+		if child + 1 < len(s.heap) && s.activities[s.heap[child + 1]] > s.activities[s.heap[child]] {
+			child++
+		}
+		if s.activities[s.heap[child]] <= s.activities[variable] {
+			break
+		}
+		s.heap[index] = s.heap[child]
+		s.heapIndices[s.heap[index]] = index
+		index = child
+	}
+	s.heap[index] = variable
+	s.heapIndices[variable] = index
+}
+
+// variables returns the current assignment, falling back to the saved phase
+// of any variable left unassigned by a timeout.
+func (s *cdclState) variables() map[string]bool {
+	variables := map[string]bool{}
+	for index, name := range s.names {
+		if s.values[index] != 0 {
+			variables[name] = s.values[index] == 1
+		} else {
+			variables[name] = s.polarities[index] == 0
+		}
+	}
+	return variables
+}
+
+// luby returns the ith element of the Luby restart sequence 1, 1, 2, 1, 1, 2, 4, ...
+func luby(i int) int {
+	size, sequence := 1, 0
+	for size < i + 1 {
+		sequence++
+		size = 2 * size + 1
+	}
+	for size - 1 != i {
+		size = (size - 1) >> 1
+		sequence--
+		i = i % size
+	}
+	return 1 << sequence
+}
@@ -0,0 +1,155 @@
+package solvers
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	u "github.com/google/uuid"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestCdclSolve(t *testing.T) {
+	cases := []struct {
+		desc string
+		job *model.Job
+		want *model.Solution
+	}{
+		{ "single clause is fully solved", singleClauseJob(), singleClauseSolution() },
+		{ "two clauses are fully solved", twoClauseJob(), twoClauseSolution() },
+		{ "big solvable job is fully solved", bigSolvableJob(rand.New(rand.NewSource(0))), bigSolvableSolution() },
+		{ "big unsolvable job is not solved", bigUnsolvableJob(rand.New(rand.NewSource(0))), withStatus(bigUnsolvableSolution(), model.SolutionStatusUnsatisfiable) },
+		{ "every sign combination is not solved", everyCombinationJob(), &model.Solution{
+				Score: 0.0,
+				Status: model.SolutionStatusUnsatisfiable,
+				Variables: []*model.SolvedVariable{ {}, {}, {} },
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			tc.want.Uuid = tc.job.Uuid
+			maxTime, _ := time.ParseDuration("10s")
+			sut := NewCdclSolver(maxTime, &factories.SolutionFactory{})
+
+			// act
+			got := sut.Solve(context.Background(), tc.job)
+
+			// assert
+			assertBigSolutionsAreEqual(t, got, tc.want)
+		})
+	}
+}
+
+func TestCdclMatchesBruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		job := randomJob(random, 3 + random.Intn(6), 1 + random.Intn(40))
+		t.Run(fmt.Sprintf("random job %d", i), func(t *testing.T) {
+			// arrange
+			maxTime, _ := time.ParseDuration("10s")
+			sut := NewCdclSolver(maxTime, &factories.SolutionFactory{})
+			want := bruteForceSatisfiable(job)
+
+			// act
+			got := sut.Solve(context.Background(), job)
+
+			// assert
+			if (got.Score == 1.0) != want {
+				t.Errorf("failed to match brute force: got score %f want satisfiable %t", got.Score, want)
+			}
+			assertStatusesAreEqual(t, got, bruteForceStatus(want))
+		})
+	}
+}
+
+func TestCdclMatchesDpll(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	for i := 0; i < 20; i++ {
+		job := randomJob(random, 40, 170)
+		t.Run(fmt.Sprintf("phase transition job %d", i), func(t *testing.T) {
+			// arrange
+			maxTime, _ := time.ParseDuration("10s")
+			factory := &factories.SolutionFactory{}
+			sut := NewCdclSolver(maxTime, factory)
+			want := NewDpllSolver(maxTime, factory).Solve(context.Background(), job)
+
+			// act
+			got := sut.Solve(context.Background(), job)
+
+			// assert
+			if (got.Score == 1.0) != (want.Score == 1.0) {
+				t.Errorf("failed to match dpll: got score %f want score %f", got.Score, want.Score)
+			}
+			assertStatusesAreEqual(t, got, want.Status)
+		})
+	}
+}
+
+func TestCdclPlanted(t *testing.T) {
+	cases := []struct {
+		desc string
+		variables int
+		clauses int
+	}{
+		{ "planted 300 variable job is fully solved", 300, 1200 },
+		{ "planted 2000 variable job is fully solved", 2000, 7000 },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			job := plantedJob(rand.New(rand.NewSource(3)), tc.variables, tc.clauses)
+			maxTime, _ := time.ParseDuration("10s")
+			sut := NewCdclSolver(maxTime, &factories.SolutionFactory{})
+
+			// act
+			got := sut.Solve(context.Background(), job)
+
+			// assert
+			if got.Score != 1.0 {
+				t.Errorf("failed to solve planted job: got score %f", got.Score)
+			}
+		})
+	}
+}
+
+func TestLuby(t *testing.T) {
+	want := []int{ 1, 1, 2, 1, 1, 2, 4, 1, 1, 2, 1, 1, 2, 4, 8, 1 }
+	for i, w := range want {
+		if got := luby(i); got != w {
+			t.Errorf("wrong luby value at %d: got %d want %d", i, got, w)
+		}
+	}
+}
+
+// plantedJob builds a random job that is guaranteed to be satisfied by a
+// hidden assignment.
+func plantedJob(random *rand.Rand, variables int, clauses int) *model.Job {
+	hidden := make([]bool, variables)
+	for i := range hidden {
+		hidden[i] = random.Intn(2) == 0
+	}
+	job := &model.Job{
+		Name: u.NewString(),
+		Clauses: []*model.Clause{},
+	}
+	for len(job.Clauses) < clauses {
+		literals := []*model.Variable{}
+		satisfied := false
+		for j := 0; j < 3; j++ {
+			index := random.Intn(variables)
+			negated := random.Intn(2) == 0
+			satisfied = satisfied || hidden[index] != negated
+			literals = append(literals, &model.Variable{ Name: fmt.Sprintf("v%d", index), Negated: negated })
+		}
+		if satisfied {
+			job.Clauses = append(job.Clauses, &model.Clause{ Literals: literals })
+		}
+	}
+	return job
+}
@@ -0,0 +1,89 @@
+package solvers
+
+import (
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+// clauseTable is a job's formula laid out for scoring bitset members: the
+// clauses are stored back to back in one slice, with literals re-encoded as
+// variable<<1|negated.
+type clauseTable struct {
+	formula *model.Formula
+	literals []int
+	starts []int
+}
+
+func newClauseTable(job *model.Job) *clauseTable {
+	formula := job.Formula()
+	t := &clauseTable{
+		formula: formula,
+		literals: []int{},
+		starts: []int{0},
+	}
+	for _, clause := range formula.Clauses {
+		for _, literal := range clause {
+			encoded := model.DecodeVariable(literal) << 1
+			if literal < 0 {
+				encoded |= 1
+			}
+			t.literals = append(t.literals, encoded)
+		}
+		t.starts = append(t.starts, len(t.literals))
+	}
+	return t
+}
+
+func (t *clauseTable) size() int {
+	return len(t.formula.Names)
+}
+
+// satisfied counts the clauses m satisfies; a literal holds when the bit of
+// its variable differs from its negated bit.
+func (t *clauseTable) satisfied(m member) int {
+	count := t.formula.Tautologies
+	for clause := 0; clause < len(t.starts) - 1; clause++ {
+		if t.clauseSatisfied(clause, m) {
+			count++
+		}
+	}
+	return count
+}
+
+func (t *clauseTable) clauseSatisfied(clause int, m member) bool {
+	for _, literal := range t.clause(clause) {
+		variable := literal >> 1
+		if int(m[variable >> 6] >> (variable & 63)) & 1 != literal & 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// unsatisfied lists the clauses m leaves unsatisfied.
+func (t *clauseTable) unsatisfied(m member) []int {
+	clauses := []int{}
+	for clause := 0; clause < len(t.starts) - 1; clause++ {
+		if !t.clauseSatisfied(clause, m) {
+			clauses = append(clauses, clause)
+		}
+	}
+	return clauses
+}
+
+// clause returns the literals of a clause, encoded as variable<<1|negated.
+func (t *clauseTable) clause(clause int) []int {
+	return t.literals[t.starts[clause]:t.starts[clause + 1]]
+}
+
+// score matches Job.Score for the assignment m stands for.
+func (t *clauseTable) score(m member) float64 {
+	return t.formula.ScoreSatisfied(t.satisfied(m))
+}
+
+func (t *clauseTable) assignment(m member) map[string]bool {
+	values := make([]bool, t.size())
+	for index := range values {
+		values[index] = m.get(index)
+	}
+	return t.formula.Assignment(values)
+}
@@ -0,0 +1,219 @@
+package solvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+type dpllSolver struct {
+	maxTime time.Duration
+	solutionFactory *factories.SolutionFactory
+}
+
+func NewDpllSolver(
+	maxTime time.Duration,
+	solutionFactory *factories.SolutionFactory,
+) *dpllSolver {
+	return &dpllSolver{
+		maxTime: maxTime,
+		solutionFactory: solutionFactory,
+	}
+}
+
+func (s *dpllSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, s.maxTime)
+	defer cancel()
+	state := newDpllState(ctx, job)
+	// report once up front, since a hard job may take a while to reach
+	// progressInterval decisions
+	state.reporter.Report(Progress{})
+	status := model.SolutionStatusUnsatisfiable
+	if state.search() {
+		status = model.SolutionStatusSatisfiable
+	} else if state.interrupted {
+		status = interruptedStatus(ctx)
+	}
+	return s.solutionFactory.ConstructSolution(state.variables(), job, state.decisions, time.Since(start), status)
+}
+
+// Clauses are shared with the job's formula, so literals are encoded as
+// +(index+1) for a variable and -(index+1) for its negation.
+type dpllState struct {
+	names []string
+	clauses [][]int
+	values []int8
+	trail []int
+	decisions int
+	ctx context.Context
+	reporter ProgressReporter
+	interrupted bool
+}
+
+func newDpllState(ctx context.Context, job *model.Job) *dpllState {
+	formula := job.Formula()
+	return &dpllState{
+		names: formula.Names,
+		clauses: formula.Clauses,
+		values: make([]int8, len(formula.Names)),
+		trail: []int{},
+		ctx: ctx,
+		reporter: progressReporter(ctx),
+	}
+}
+
+func (s *dpllState) search() bool {
+	if s.ctx.Err() != nil {
+		s.interrupted = true
+		return false
+	}
+	mark := len(s.trail)
+	if !s.propagate() {
+		s.undo(mark)
+		return false
+	}
+	s.eliminatePureLiterals()
+	variable := s.chooseVariable()
+	if variable < 0 {
+		return true
+	}
+	for _, value := range []int8{1, -1} {
+		s.decisions++
+		if s.decisions % progressInterval == 0 {
+			s.reporter.Report(Progress{Cycles: s.decisions})
+		}
+		branch := len(s.trail)
+		s.assign(variable, value)
+		if s.search() {
+			return true
+		}
+		s.undo(branch)
+		if s.interrupted {
+			break
+		}
+	}
+	s.undo(mark)
+	return false
+}
+
+// propagate repeatedly assigns the remaining literal of every unit clause and
+// reports false as soon as a clause has all of its literals falsified.
+func (s *dpllState) propagate() bool {
+	changed := true
+	for changed {
+		changed = false
+		for _, clause := range s.clauses {
+			satisfied, unassigned, last := s.inspect(clause)
+			if satisfied {
+				continue
+			}
+			if unassigned == 0 {
+				return false
+			}
+			if unassigned == 1 {
+				s.assignLiteral(last)
+				changed = true
+			}
+		}
+	}
+	return true
+}
+
+func (s *dpllState) eliminatePureLiterals() {
+	polarities := make([]int8, len(s.values))
+	for _, clause := range s.clauses {
+		if satisfied, _, _ := s.inspect(clause); satisfied {
+			continue
+		}
+		for _, literal := range clause {
+			variable, value := decodeLiteral(literal)
+			if s.values[variable] != 0 {
+				continue
+			}
+			if polarities[variable] == 0 {
+				polarities[variable] = value
+			} else if polarities[variable] != value {
+				polarities[variable] = 2
+			}
+		}
+	}
+	for variable, polarity := range polarities {
+		if polarity == 1 || polarity == -1 {
+			s.assign(variable, polarity)
+		}
+	}
+}
+
+// chooseVariable picks the unassigned variable occurring most often in clauses
+// that are not yet satisfied, or -1 when every clause is satisfied.
+func (s *dpllState) chooseVariable() int {
+	counts := make([]int, len(s.values))
+	best := -1
+	for _, clause := range s.clauses {
+		if satisfied, _, _ := s.inspect(clause); satisfied {
+			continue
+		}
+		for _, literal := range clause {
+			variable, _ := decodeLiteral(literal)
+			if s.values[variable] != 0 {
+				continue
+			}
+			counts[variable]++
+			if best < 0 || counts[variable] > counts[best] {
+				best = variable
+			}
+		}
+	}
+	return best
+}
+
+func (s *dpllState) inspect(clause []int) (bool, int, int) {
+	unassigned := 0
+	last := 0
+	for _, literal := range clause {
+		variable, value := decodeLiteral(literal)
+		if s.values[variable] == value {
+			return true, 0, 0
+		}
+		if s.values[variable] == 0 {
+			unassigned++
+			last = literal
+		}
+	}
+	return false, unassigned, last
+}
+
+func (s *dpllState) assignLiteral(literal int) {
+	variable, value := decodeLiteral(literal)
+	s.assign(variable, value)
+}
+
+func (s *dpllState) assign(variable int, value int8) {
+	s.values[variable] = value
+	s.trail = append(s.trail, variable)
+}
+
+func (s *dpllState) undo(mark int) {
+	for _, variable := range s.trail[mark:] {
+		s.values[variable] = 0
+	}
+	s.trail = s.trail[:mark]
+}
+
+func (s *dpllState) variables() map[string]bool {
+	variables := map[string]bool{}
+	for index, name := range s.names {
+		variables[name] = s.values[index] == 1
+	}
+	return variables
+}
+
+func decodeLiteral(literal int) (int, int8) {
+	if literal < 0 {
+		return -literal - 1, -1
+	}
+	return literal - 1, 1
+}
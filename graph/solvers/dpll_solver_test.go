@@ -0,0 +1,150 @@
+package solvers
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	u "github.com/google/uuid"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestDpllSolve(t *testing.T) {
+	cases := []struct {
+		desc string
+		job *model.Job
+		want *model.Solution
+	}{
+		{ "single clause is fully solved", singleClauseJob(), singleClauseSolution() },
+		{ "two clauses are fully solved", twoClauseJob(), twoClauseSolution() },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			tc.want.Uuid = tc.job.Uuid
+			maxTime, _ := time.ParseDuration("1s")
+			sut := NewDpllSolver(maxTime, &factories.SolutionFactory{})
+
+			// act
+			got := sut.Solve(context.Background(), tc.job)
+
+			// assert
+			assertBigSolutionsAreEqual(t, got, tc.want)
+		})
+	}
+}
+
+func TestDpllUnsatisfiable(t *testing.T) {
+	cases := []struct {
+		desc string
+		job *model.Job
+	}{
+		{ "every sign combination of three variables", everyCombinationJob() },
+		{ "contradicting variable among random clauses", bigUnsolvableJob(rand.New(rand.NewSource(0))) },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			maxTime, _ := time.ParseDuration("10s")
+			sut := NewDpllSolver(maxTime, &factories.SolutionFactory{})
+
+			// act
+			got := sut.Solve(context.Background(), tc.job)
+
+			// assert
+			if got.Score >= 1.0 {
+				t.Errorf("unsatisfiable job reported as solved: score %f", got.Score)
+			}
+			assertStatusesAreEqual(t, got, model.SolutionStatusUnsatisfiable)
+		})
+	}
+}
+
+func TestDpllMatchesBruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	for i := 0; i < 200; i++ {
+		job := randomJob(random, 3 + random.Intn(6), 1 + random.Intn(40))
+		t.Run(fmt.Sprintf("random job %d", i), func(t *testing.T) {
+			// arrange
+			maxTime, _ := time.ParseDuration("10s")
+			sut := NewDpllSolver(maxTime, &factories.SolutionFactory{})
+			want := bruteForceSatisfiable(job)
+
+			// act
+			got := sut.Solve(context.Background(), job)
+
+			// assert
+			if (got.Score == 1.0) != want {
+				t.Errorf("failed to match brute force: got score %f want satisfiable %t", got.Score, want)
+			}
+			assertStatusesAreEqual(t, got, bruteForceStatus(want))
+		})
+	}
+}
+
+func bruteForceSatisfiable(job *model.Job) bool {
+	names := job.Variables()
+	for bits := 0; bits < 1 << len(names); bits++ {
+		variables := map[string]bool{}
+		for index, name := range names {
+			variables[name] = bits & (1 << index) != 0
+		}
+		if job.Score(variables) == 1.0 {
+			return true
+		}
+	}
+	return false
+}
+
+func bruteForceStatus(satisfiable bool) model.SolutionStatus {
+	if satisfiable {
+		return model.SolutionStatusSatisfiable
+	}
+	return model.SolutionStatusUnsatisfiable
+}
+
+func withStatus(solution *model.Solution, status model.SolutionStatus) *model.Solution {
+	solution.Status = status
+	return solution
+}
+
+func randomJob(random *rand.Rand, variables int, clauses int) *model.Job {
+	job := &model.Job{
+		Name: u.NewString(),
+		Clauses: []*model.Clause{},
+	}
+	randomVariable := func() *model.Variable {
+		return &model.Variable{ Name: fmt.Sprintf("v%d", random.Intn(variables)), Negated: random.Intn(2) == 0 }
+	}
+	for i := 0; i < clauses; i++ {
+		job.Clauses = append(job.Clauses, &model.Clause{
+			Literals: []*model.Variable{
+				randomVariable(),
+				randomVariable(),
+				randomVariable(),
+			},
+		})
+	}
+	return job
+}
+
+func everyCombinationJob() *model.Job {
+	job := &model.Job{
+		Name: u.NewString(),
+		Clauses: []*model.Clause{},
+	}
+	for bits := 0; bits < 8; bits++ {
+		job.Clauses = append(job.Clauses, &model.Clause{
+			Literals: []*model.Variable{
+				{ Name: "v1", Negated: bits & 1 != 0 },
+				{ Name: "v2", Negated: bits & 2 != 0 },
+				{ Name: "v3", Negated: bits & 4 != 0 },
+			},
+		})
+	}
+	return job
+}
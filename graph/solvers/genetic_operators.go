@@ -0,0 +1,239 @@
+package solvers
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+// adaptiveMutationPatience is how many generations adaptive mutation waits for
+// the best score to improve before raising its rate, and
+// maxAdaptiveMutationScale caps how far it raises the rate.
+const (
+	adaptiveMutationPatience = 50
+	maxAdaptiveMutationScale = 4.0
+)
+
+// GeneticOperators picks how the genetic solver selects parents, crosses them
+// over and mutates the children. Fields left empty fall back to roulette
+// selection, uniform crossover and fixed mutation, the operators the solver
+// used before they could be chosen. TournamentSize only matters for tournament
+// selection. A positive RefinementFlips makes the solver memetic, refining
+// children with WalkSAT at the given Noise with probability
+// RefinementProbability.
+type GeneticOperators struct {
+	Selection model.SelectionOperator
+	Crossover model.CrossoverOperator
+	Mutation model.MutationOperator
+	TournamentSize int
+	RefinementFlips int
+	RefinementProbability float64
+	Noise float64
+}
+
+// geneticOperators holds the operators of one run, since adaptive mutation
+// and refinement keep state between generations. refinement is nil unless
+// the solver is memetic.
+type geneticOperators struct {
+	selection selection
+	crossover crossover
+	mutation mutation
+	refinement *refinement
+}
+
+func (o GeneticOperators) build(mutationRate float64, table *clauseTable) *geneticOperators {
+	operators := &geneticOperators{
+		selection: rouletteSelection{},
+		crossover: uniformCrossover{},
+		mutation: &fixedMutation{rate: mutationRate},
+	}
+	if o.RefinementFlips > 0 {
+		operators.refinement = newRefinement(table, o.RefinementFlips, o.RefinementProbability, o.Noise)
+	}
+	switch o.Selection {
+	case model.SelectionOperatorRank:
+		operators.selection = rankSelection{}
+	case model.SelectionOperatorTournament:
+		operators.selection = tournamentSelection{size: o.TournamentSize}
+	}
+	switch o.Crossover {
+	case model.CrossoverOperatorOnePoint:
+		operators.crossover = onePointCrossover{}
+	case model.CrossoverOperatorTwoPoint:
+		operators.crossover = twoPointCrossover{}
+	}
+	switch o.Mutation {
+	case model.MutationOperatorAdaptive:
+		operators.mutation = &adaptiveMutation{baseRate: mutationRate, rate: mutationRate}
+	case model.MutationOperatorFocused:
+		operators.mutation = &focusedMutation{rate: mutationRate}
+	}
+	return operators
+}
+
+// selection prepares a population, given the scores of its members, for
+// drawing parents.
+type selection interface {
+	parents(scores []float64) parentPool
+}
+
+// parentPool draws the index of a parent.
+type parentPool interface {
+	selectMember(random *rand.Rand) int
+}
+
+// rouletteSelection draws members in proportion to their score.
+type rouletteSelection struct {
+}
+
+func (s rouletteSelection) parents(scores []float64) parentPool {
+	return newMemberRanks(scores)
+}
+
+// rankSelection draws members in proportion to their rank, the best of n
+// members weighing n and the worst 1, so a few strong members can't crowd
+// out the rest once scores are close together.
+type rankSelection struct {
+}
+
+func (s rankSelection) parents(scores []float64) parentPool {
+	weights := make([]float64, len(scores))
+	for rank, index := range orderByScore(scores) {
+		weights[index] = float64(rank + 1)
+	}
+	return newMemberRanks(weights)
+}
+
+// tournamentSelection draws size members at random and keeps the best.
+type tournamentSelection struct {
+	size int
+}
+
+type tournament struct {
+	scores []float64
+	size int
+}
+
+func (s tournamentSelection) parents(scores []float64) parentPool {
+	return tournament{scores: scores, size: s.size}
+}
+
+func (t tournament) selectMember(random *rand.Rand) int {
+	winner := random.Intn(len(t.scores))
+	for round := 1; round < t.size; round++ {
+		contender := random.Intn(len(t.scores))
+		if t.scores[contender] > t.scores[winner] {
+			winner = contender
+		}
+	}
+	return winner
+}
+
+// crossover combines two parents holding size variables into a child.
+type crossover interface {
+	cross(parent1 member, parent2 member, size int, random *rand.Rand) member
+}
+
+type uniformCrossover struct {
+}
+
+func (c uniformCrossover) cross(parent1 member, parent2 member, size int, random *rand.Rand) member {
+	return parent1.crossOver(parent2, random)
+}
+
+// onePointCrossover takes the variables before a random cut from the first
+// parent and the rest from the second.
+type onePointCrossover struct {
+}
+
+func (c onePointCrossover) cross(parent1 member, parent2 member, size int, random *rand.Rand) member {
+	if size < 2 {
+		return parent1.clone()
+	}
+	return parent1.splice(parent2, 1 + random.Intn(size - 1), size)
+}
+
+// twoPointCrossover takes the variables between two random cuts from the
+// second parent and the rest from the first.
+type twoPointCrossover struct {
+}
+
+func (c twoPointCrossover) cross(parent1 member, parent2 member, size int, random *rand.Rand) member {
+	from := random.Intn(size + 1)
+	to := random.Intn(size + 1)
+	if from > to {
+		from, to = to, from
+	}
+	return parent1.splice(parent2, from, to)
+}
+
+// mutation returns a mutated copy of a child. adapt is told after every
+// generation whether the best score improved.
+type mutation interface {
+	mutate(child member, table *clauseTable, random *rand.Rand) member
+	adapt(improved bool)
+}
+
+// fixedMutation flips every variable with the same probability.
+type fixedMutation struct {
+	rate float64
+}
+
+func (m *fixedMutation) mutate(child member, table *clauseTable, random *rand.Rand) member {
+	return child.mutate(m.rate, table.size(), random)
+}
+
+func (m *fixedMutation) adapt(improved bool) {
+}
+
+// adaptiveMutation doubles its rate after every adaptiveMutationPatience
+// generations that fail to improve the best score, up to
+// maxAdaptiveMutationScale times the base rate, and goes back to the base
+// rate once the score improves, to shake a stagnating population loose.
+type adaptiveMutation struct {
+	baseRate float64
+	rate float64
+	stagnant int
+}
+
+func (m *adaptiveMutation) mutate(child member, table *clauseTable, random *rand.Rand) member {
+	return child.mutate(m.rate, table.size(), random)
+}
+
+func (m *adaptiveMutation) adapt(improved bool) {
+	if improved {
+		m.rate = m.baseRate
+		m.stagnant = 0
+		return
+	}
+	m.stagnant++
+	if m.stagnant % adaptiveMutationPatience == 0 {
+		m.rate = math.Min(2 * m.rate, maxAdaptiveMutationScale * m.baseRate)
+	}
+}
+
+// focusedMutation makes as many flips as fixed mutation would on average, but
+// only flips variables of clauses the child leaves unsatisfied, picking a
+// clause and then one of its variables at random for every flip, like a
+// WalkSAT random walk step.
+type focusedMutation struct {
+	rate float64
+}
+
+func (m *focusedMutation) mutate(child member, table *clauseTable, random *rand.Rand) member {
+	mutated := child.clone()
+	unsatisfied := table.unsatisfied(child)
+	logKeep := math.Log1p(-m.rate)
+	if len(unsatisfied) == 0 || m.rate <= 0 || logKeep == 0 {
+		return mutated
+	}
+	for variable := geometricGap(logKeep, random); variable < table.size(); variable += 1 + geometricGap(logKeep, random) {
+		literals := table.clause(unsatisfied[random.Intn(len(unsatisfied))])
+		mutated.flip(literals[random.Intn(len(literals))] >> 1)
+	}
+	return mutated
+}
+
+func (m *focusedMutation) adapt(improved bool) {
+}
@@ -0,0 +1,212 @@
+package solvers
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestSolveWithOperators(t *testing.T) {
+	maxTime, _ := time.ParseDuration("10s")
+	factory := &factories.SolutionFactory{}
+	cases := []struct {
+		desc string
+		operators GeneticOperators
+	}{
+		{ "defaults", GeneticOperators{} },
+		{ "rank selection", GeneticOperators{ Selection: model.SelectionOperatorRank } },
+		{ "tournament selection", GeneticOperators{ Selection: model.SelectionOperatorTournament, TournamentSize: 3 } },
+		{ "one-point crossover", GeneticOperators{ Crossover: model.CrossoverOperatorOnePoint } },
+		{ "two-point crossover", GeneticOperators{ Crossover: model.CrossoverOperatorTwoPoint } },
+		{ "adaptive mutation", GeneticOperators{ Mutation: model.MutationOperatorAdaptive } },
+		{ "focused mutation", GeneticOperators{ Mutation: model.MutationOperatorFocused } },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			randomFactory := &factories.SeededRandomFactory{Seed: 1}
+			job := plantedJob(rand.New(rand.NewSource(0)), 20, 80)
+			sut := NewGeneticSolver(20, maxTime, 0, 0.05, tc.operators, factory, NewPopulationGenerator(randomFactory), randomFactory)
+
+			// act
+			got := sut.Solve(context.Background(), job)
+
+			// assert
+			assertStatusesAreEqual(t, got, model.SolutionStatusSatisfiable)
+		})
+	}
+}
+
+func TestSelection(t *testing.T) {
+	scores := []float64{ 0.5, 0.9, 0.1, 0.7 }
+	cases := []struct {
+		desc string
+		sut selection
+		want []int
+	}{
+		{ "roulette draws in proportion to score", rouletteSelection{}, []int{ 1, 3, 0, 2 } },
+		{ "rank draws in proportion to rank", rankSelection{}, []int{ 1, 3, 0, 2 } },
+		{ "tournament draws the best of its entrants", tournamentSelection{size: 3}, []int{ 1, 3, 0, 2 } },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			random := rand.New(rand.NewSource(1))
+			pool := tc.sut.parents(scores)
+			counts := make([]int, len(scores))
+
+			// act
+			for i := 0; i < 10000; i++ {
+				counts[pool.selectMember(random)]++
+			}
+
+			// assert
+			for index := 1; index < len(tc.want); index++ {
+				if counts[tc.want[index]] >= counts[tc.want[index - 1]] {
+					t.Errorf("member %d drawn as often as member %d: got counts %v", tc.want[index], tc.want[index - 1], counts)
+				}
+			}
+		})
+	}
+}
+
+func TestCrossover(t *testing.T) {
+	size := 130
+	cases := []struct {
+		desc string
+		sut crossover
+		segments int
+	}{
+		{ "one-point crossover switches parents once", onePointCrossover{}, 2 },
+		{ "two-point crossover switches parents at most twice", twoPointCrossover{}, 3 },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			random := rand.New(rand.NewSource(1))
+			parent1 := allOf(size, false)
+			parent2 := allOf(size, true)
+
+			for i := 0; i < 100; i++ {
+				// act
+				got := tc.sut.cross(parent1, parent2, size, random)
+
+				// assert
+				segments := 1
+				for variable := 1; variable < size; variable++ {
+					if got.get(variable) != got.get(variable - 1) {
+						segments++
+					}
+				}
+				if segments > tc.segments {
+					t.Fatalf("wrong number of segments: got %d want at most %d", segments, tc.segments)
+				}
+				if got[len(got) - 1] >> (size % 64) != 0 {
+					t.Fatalf("set bits past the last variable: %x", got[len(got) - 1])
+				}
+			}
+		})
+	}
+}
+
+func TestMemberSplice(t *testing.T) {
+	cases := []struct {
+		desc string
+		from int
+		to int
+	}{
+		{ "nothing", 10, 10 },
+		{ "within a word", 3, 17 },
+		{ "across words", 60, 129 },
+		{ "whole member", 0, 130 },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			parent1 := allOf(130, false)
+			parent2 := allOf(130, true)
+
+			// act
+			got := parent1.splice(parent2, tc.from, tc.to)
+
+			// assert
+			for variable := 0; variable < 130; variable++ {
+				want := variable >= tc.from && variable < tc.to
+				if got.get(variable) != want {
+					t.Errorf("wrong variable %d: got %t want %t", variable, got.get(variable), want)
+				}
+			}
+		})
+	}
+}
+
+func TestAdaptiveMutation(t *testing.T) {
+	cases := []struct {
+		desc string
+		improvements []bool
+		want float64
+	}{
+		{ "keeps the base rate while patient", stagnation(adaptiveMutationPatience - 1), 0.05 },
+		{ "doubles the rate once patience runs out", stagnation(adaptiveMutationPatience), 0.1 },
+		{ "doubles the rate again after more stagnation", stagnation(2 * adaptiveMutationPatience), 0.2 },
+		{ "caps the rate", stagnation(5 * adaptiveMutationPatience), 0.05 * maxAdaptiveMutationScale },
+		{ "resets the rate on improvement", append(stagnation(2 * adaptiveMutationPatience), true), 0.05 },
+		{ "waits for full patience after improvement", append(append(stagnation(adaptiveMutationPatience - 1), true), stagnation(adaptiveMutationPatience - 1)...), 0.05 },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := &adaptiveMutation{baseRate: 0.05, rate: 0.05}
+
+			// act
+			for _, improved := range tc.improvements {
+				sut.adapt(improved)
+			}
+
+			// assert
+			if sut.rate != tc.want {
+				t.Errorf("wrong rate: got %f want %f", sut.rate, tc.want)
+			}
+		})
+	}
+}
+
+func stagnation(generations int) []bool {
+	return make([]bool, generations)
+}
+
+func TestFocusedMutation(t *testing.T) {
+	// arrange
+	job := randomJob(rand.New(rand.NewSource(0)), 200, 200)
+	table := newClauseTable(job)
+	random := rand.New(rand.NewSource(1))
+	child := (&PopulationGenerator{}).generateMember(table.size(), random)
+	focused := map[int]bool{}
+	for _, clause := range table.unsatisfied(child) {
+		for _, literal := range table.clause(clause) {
+			focused[literal >> 1] = true
+		}
+	}
+	sut := &focusedMutation{rate: 0.1}
+
+	// act
+	got := sut.mutate(child, table, random)
+
+	// assert
+	flips := 0
+	for variable := 0; variable < table.size(); variable++ {
+		if got.get(variable) != child.get(variable) {
+			flips++
+			if !focused[variable] {
+				t.Errorf("flipped variable %d outside the unsatisfied clauses", variable)
+			}
+		}
+	}
+	if flips == 0 {
+		t.Errorf("failed to flip any variable")
+	}
+}
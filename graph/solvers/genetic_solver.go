@@ -1,6 +1,7 @@
 package solvers
 
 import (
+	"context"
 	"math/rand"
 	"time"
 
@@ -11,6 +12,9 @@ import (
 type geneticSolver struct {
 	maxPopulation int
 	maxTime time.Duration
+	maxGenerations int
+	mutationRate float64
+	operators GeneticOperators
 	solutionFactory *factories.SolutionFactory
 	populationGenerator *PopulationGenerator
 	randomFactory factories.RandomFactory
@@ -19,6 +23,9 @@ type geneticSolver struct {
 func NewGeneticSolver(
 	maxPopulation int,
 	maxTime time.Duration,
+	maxGenerations int,
+	mutationRate float64,
+	operators GeneticOperators,
 	solutionFactory *factories.SolutionFactory,
 	populationGenerator *PopulationGenerator,
 	randomFactory factories.RandomFactory,
@@ -26,65 +33,194 @@ func NewGeneticSolver(
 	return &geneticSolver{
 		maxPopulation: maxPopulation,
 		maxTime: maxTime,
+		maxGenerations: maxGenerations,
+		mutationRate: mutationRate,
+		operators: operators,
 		solutionFactory: solutionFactory,
 		populationGenerator: populationGenerator,
 		randomFactory: randomFactory,
 	}
 }
 
-func (s *geneticSolver) Solve(job *model.Job) *model.Solution {
+func (s *geneticSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
 	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, s.maxTime)
+	defer cancel()
 	random := s.randomFactory.Build()
-	population := s.populationGenerator.generatePopulation(s.maxPopulation, job.Variables())
-	bestMember, cycles := s.start(job, population, random)
+	table := newClauseTable(job)
+	population, err := s.populationGenerator.generatePopulation(ctx, s.maxPopulation, table.size())
+	island := s.newIsland(table, population, random)
+	history := newHistoryRecorder(1)
+	var bestMember member
+	var cycles int
+	var status model.SolutionStatus
+	if err != nil {
+		bestMember, status = s.interrupted(ctx, table, island, history)
+	} else {
+		bestMember, cycles, status = s.start(ctx, table, island, history)
+	}
 	elapsed := time.Since(start)
-	return s.solutionFactory.ConstructSolution(bestMember, job, cycles, elapsed)
+	solution := s.solutionFactory.ConstructSolution(table.assignment(bestMember), job, cycles, elapsed, status)
+	solution.History = history.points
+	return solution
 }
 
-func (s *geneticSolver) start(job *model.Job, population population, random *rand.Rand) (member, int) {
-	cycles := 0
-	var bestMember member
-	if len(population) < s.maxPopulation {
-		bestMember, _ = population.best(job)
-	} else {
-		bestMember, cycles = s.evolve(job, population, random)
+// island is a population evolving on its own, along with the operators and
+// random source it breeds with and the best member it has seen.
+type island struct {
+	population population
+	operators *geneticOperators
+	random *rand.Rand
+	bestMember member
+	bestScore float64
+	generations int
+}
+
+func (s *geneticSolver) newIsland(table *clauseTable, population population, random *rand.Rand) *island {
+	bestMember, bestScore := population.best(table)
+	return &island{
+		population: population,
+		operators: s.operators.build(s.mutationRate, table),
+		random: random,
+		bestMember: bestMember,
+		bestScore: bestScore,
 	}
-	return bestMember, cycles
 }
 
-func (s *geneticSolver) evolve(job *model.Job, population population, random *rand.Rand) (member, int) {
-	start := time.Now()
-	cycles := 0
-	bestMember, bestScore := population.best(job)
-	for time.Since(start) < s.maxTime && bestScore < 1.0 - 0.0001 {
-		population = s.reproduce(job, population, bestMember, random)
-		bestMember, bestScore = population.best(job)
-		cycles++
+func (i *island) solved() bool {
+	return i.bestScore >= 1.0 - 0.0001
+}
+
+func (s *geneticSolver) start(ctx context.Context, table *clauseTable, island *island, history *historyRecorder) (member, int, model.SolutionStatus) {
+	if len(island.population) < s.maxPopulation {
+		// the population already holds every possible assignment
+		history.finish(s.historyPoint(table, island.population, 0, island.bestScore))
+		return island.bestMember, 0, exhaustiveStatus(island.bestScore)
+	}
+	s.evolve(ctx, table, island, history)
+	return island.bestMember, island.generations, s.status(ctx, island.bestMember, table)
+}
+
+// interrupted gives up on an island whose population ctx ended before it was
+// fully generated, settling for its best member.
+func (s *geneticSolver) interrupted(ctx context.Context, table *clauseTable, island *island, history *historyRecorder) (member, model.SolutionStatus) {
+	history.finish(s.historyPoint(table, island.population, 0, island.bestScore))
+	return island.bestMember, interruptedStatus(ctx)
+}
+
+func exhaustiveStatus(bestScore float64) model.SolutionStatus {
+	if bestScore == 1.0 {
+		return model.SolutionStatusSatisfiable
+	}
+	return model.SolutionStatusUnsatisfiable
+}
+
+// status tells why evolution stopped at bestMember: it solved the job, ran
+// out of time, was cancelled or used up maxGenerations.
+func (s *geneticSolver) status(ctx context.Context, bestMember member, table *clauseTable) model.SolutionStatus {
+	if table.score(bestMember) == 1.0 {
+		return model.SolutionStatusSatisfiable
+	}
+	if ctx.Err() != nil {
+		return interruptedStatus(ctx)
 	}
-	return bestMember, cycles
+	return model.SolutionStatusUnknown
 }
 
-func (s *geneticSolver) reproduce(job *model.Job, _population population, bestMember member, random *rand.Rand) population {
+// generationsLeft reports whether evolution may go past generation, which it
+// always may when maxGenerations is zero.
+func (s *geneticSolver) generationsLeft(generation int) bool {
+	return s.maxGenerations == 0 || generation < s.maxGenerations
+}
+
+func (s *geneticSolver) evolve(ctx context.Context, table *clauseTable, island *island, history *historyRecorder) {
+	reporter := progressReporter(ctx)
+	reporter.Report(scoredProgress(island.generations, island.bestScore, table.assignment(island.bestMember)))
+	history.record(s.historyPoint(table, island.population, island.generations, island.bestScore))
+	for ctx.Err() == nil && !island.solved() && s.generationsLeft(island.generations) {
+		var improved map[string]bool
+		if s.generation(ctx, table, island) {
+			improved = table.assignment(island.bestMember)
+		}
+		reporter.Report(scoredProgress(island.generations, island.bestScore, improved))
+		if history.due(island.generations) {
+			history.record(s.historyPoint(table, island.population, island.generations, island.bestScore))
+		}
+	}
+	history.finish(s.historyPoint(table, island.population, island.generations, island.bestScore))
+}
+
+// generation breeds the next population of an island and reports whether it
+// improved on the island's best score.
+func (s *geneticSolver) generation(ctx context.Context, table *clauseTable, island *island) bool {
+	island.population = s.reproduce(ctx, table, island.operators, island.population, island.bestMember, island.random)
+	previousScore := island.bestScore
+	island.bestMember, island.bestScore = island.population.best(table)
+	island.generations++
+	improved := island.bestScore > previousScore
+	island.operators.mutation.adapt(improved)
+	return improved
+}
+
+func (s *geneticSolver) historyPoint(table *clauseTable, population population, cycles int, bestScore float64) *model.HistoryPoint {
+	averageScore := population.averageScore(table)
+	diversity := population.diversity(table.size())
+	return &model.HistoryPoint{
+		Cycle: cycles,
+		BestScore: bestScore,
+		AverageScore: &averageScore,
+		Diversity: &diversity,
+	}
+}
+
+// reproduce breeds a population of distinct members, unless ctx ends first,
+// after which it lets duplicates in so that it can finish quickly.
+func (s *geneticSolver) reproduce(ctx context.Context, table *clauseTable, operators *geneticOperators, _population population, bestMember member, random *rand.Rand) population {
 	newPop := population{
 		bestMember,
 	}
-	rankedPop := _population.rank(job)
+	members := newMemberSet(newPop)
+	parents := operators.selection.parents(_population.scores(table))
 	for i := len(newPop); i < len(_population); i++ {
-		child := s.breed(rankedPop, _population, random)
-		for newPop.memberExists(child) {
-			child = s.breed(rankedPop, _population, random)
+		child := s.breed(table, operators, parents, _population, random)
+		for members.contains(child) && ctx.Err() == nil {
+			child = s.breed(table, operators, parents, _population, random)
 		}
+		child = s.refine(operators, members, child, random)
+		members.add(child)
 		newPop = append(newPop, child)
 	}
 	return newPop
 }
 
-func (s *geneticSolver) breed(memberRanks memberRanks, population population, random *rand.Rand) member {
-	parent1 := memberRanks.selectMember(random)
-	parent2 := memberRanks.selectMember(random)
-	for parent1 == parent2 {
-		parent2 = memberRanks.selectMember(random)
+// refine returns the child as refinement left it, or the child itself when
+// the solver isn't memetic or refinement led to a member the population
+// already holds, as local search tends to lead children to the same optimum.
+func (s *geneticSolver) refine(operators *geneticOperators, members memberSet, child member, random *rand.Rand) member {
+	if operators.refinement == nil {
+		return child
+	}
+	refined := operators.refinement.refine(child, random)
+	if members.contains(refined) {
+		return child
+	}
+	return refined
+}
+
+// maxParentDraws bounds the draws for a second parent, since a selection
+// that strongly favours one member, such as a tournament as large as the
+// population, may keep drawing the first parent again.
+const maxParentDraws = 10
+
+func (s *geneticSolver) breed(table *clauseTable, operators *geneticOperators, parents parentPool, population population, random *rand.Rand) member {
+	parent1 := parents.selectMember(random)
+	parent2 := parents.selectMember(random)
+	for draw := 1; parent1 == parent2 && draw < maxParentDraws; draw++ {
+		parent2 = parents.selectMember(random)
+	}
+	if parent1 == parent2 {
+		parent2 = (parent1 + 1 + random.Intn(len(population) - 1)) % len(population)
 	}
-	crossed := population[parent1].crossOver(population[parent2], random)
-	return crossed.mutate(random)
+	crossed := operators.crossover.cross(population[parent1], population[parent2], table.size(), random)
+	return operators.mutation.mutate(crossed, table, random)
 }
@@ -1,6 +1,7 @@
 package solvers
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -20,6 +21,16 @@ func TestSolve(t *testing.T) {
 	}{
 		{ "single clause is fully solved", singleClauseJob(), singleClauseSolution() },
 		{ "two clauses are fully solved", twoClauseJob(), twoClauseSolution() },
+		{ "every sign combination is proved unsatisfiable", everyCombinationJob(), &model.Solution{
+				Score: 0.875,
+				Status: model.SolutionStatusUnsatisfiable,
+				Variables: []*model.SolvedVariable{
+					{ Name: "v1", Value: true },
+					{ Name: "v2", Value: true },
+					{ Name: "v3", Value: true },
+				},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -30,10 +41,10 @@ func TestSolve(t *testing.T) {
 			factory := &factories.SolutionFactory{}
 			randomFactory := &factories.ZeroRandomFactory{}
 			populationGenerator := &PopulationGenerator{randomFactory: randomFactory}
-			sut := NewGeneticSolver(maxPopulation, maxTime, factory, populationGenerator, randomFactory)
+			sut := NewGeneticSolver(maxPopulation, maxTime, 0, 0.01, GeneticOperators{}, factory, populationGenerator, randomFactory)
 
 			// act
-			got := sut.Solve(tc.job)
+			got := sut.Solve(context.Background(), tc.job)
 
 			// assert
 			assertSolutionsAreEqual(t, got, tc.want)
@@ -58,13 +69,14 @@ func TestBigSolvable(t *testing.T) {
 			maxTime, _ := time.ParseDuration("10s")
 			factory := &factories.SolutionFactory{}
 			populationGenerator := &PopulationGenerator{randomFactory: randomFactory}
-			sut := NewGeneticSolver(maxPopulation, maxTime, factory, populationGenerator, randomFactory)
+			sut := NewGeneticSolver(maxPopulation, maxTime, 0, 0.01, GeneticOperators{}, factory, populationGenerator, randomFactory)
 
 			// act
-			got := sut.Solve(tc.job)
+			got := sut.Solve(context.Background(), tc.job)
 
 			// assert
 			assertBigSolutionsAreEqual(t, got, tc.want)
+			assertStatusesAreEqual(t, got, model.SolutionStatusSatisfiable)
 			fmt.Printf("cycles: %d\n", got.Cycles)
 		})
 	}
@@ -87,21 +99,63 @@ func TestBigUnsolvable(t *testing.T) {
 			maxTime, _ := time.ParseDuration("1s")
 			factory := &factories.SolutionFactory{}
 			populationGenerator := &PopulationGenerator{randomFactory: randomFactory}
-			sut := NewGeneticSolver(maxPopulation, maxTime, factory, populationGenerator, randomFactory)
+			sut := NewGeneticSolver(maxPopulation, maxTime, 0, 0.01, GeneticOperators{}, factory, populationGenerator, randomFactory)
 
 			// act
-			got := sut.Solve(tc.job)
+			got := sut.Solve(context.Background(), tc.job)
 
 			// assert
 			assertBigSolutionsAreEqual(t, got, tc.want)
+			assertStatusesAreEqual(t, got, model.SolutionStatusTimeout)
 		})
 	}
 }
 
+func TestSolveWithTournamentsLargerThanPopulation(t *testing.T) {
+	// arrange
+	randomFactory := &factories.ZeroRandomFactory{}
+	maxTime, _ := time.ParseDuration("500ms")
+	operators := GeneticOperators{ Selection: model.SelectionOperatorTournament, TournamentSize: 50 }
+	populationGenerator := &PopulationGenerator{randomFactory: randomFactory}
+	sut := NewGeneticSolver(4, maxTime, 0, 0.01, operators, &factories.SolutionFactory{}, populationGenerator, randomFactory)
+
+	// act
+	start := time.Now()
+	got := sut.Solve(context.Background(), bigUnsolvableJob(rand.New(rand.NewSource(0))))
+
+	// assert
+	if time.Since(start) > 2 * maxTime {
+		t.Errorf("failed to stop within its time limit: took %v", time.Since(start))
+	}
+	assertStatusesAreEqual(t, got, model.SolutionStatusTimeout)
+}
+
+func TestSolveWithHugePopulation(t *testing.T) {
+	// arrange
+	randomFactory := &factories.ZeroRandomFactory{}
+	maxTime, _ := time.ParseDuration("200ms")
+	populationGenerator := &PopulationGenerator{randomFactory: randomFactory}
+	sut := NewGeneticSolver(200000, maxTime, 0, 0.01, GeneticOperators{}, &factories.SolutionFactory{}, populationGenerator, randomFactory)
+
+	// act
+	start := time.Now()
+	got := sut.Solve(context.Background(), randomJob(rand.New(rand.NewSource(0)), 200, 850))
+
+	// assert
+	if time.Since(start) > 2 * maxTime {
+		t.Errorf("failed to stop within its time limit: took %v", time.Since(start))
+	}
+	assertStatusesAreEqual(t, got, model.SolutionStatusTimeout)
+	if len(got.Variables) != 200 {
+		t.Errorf("failed to return best assignment so far: got %d variables want %d", len(got.Variables), 200)
+	}
+}
+
 func assertSolutionsAreEqual(t testing.TB, got *model.Solution, want *model.Solution) {
 	if got.Uuid.String() != want.Uuid.String() {
 		t.Errorf("failed to match uuid on solutions: got '%s' want '%s'", got.Uuid.String(), want.Uuid.String())
 	}
+	assertStatusesAreEqual(t, got, want.Status)
 	if got.Score != want.Score {
 		t.Errorf("failed to match score: got %f want %f", got.Score, want.Score)
 	}
@@ -115,6 +169,9 @@ func assertBigSolutionsAreEqual(t testing.TB, got *model.Solution, want *model.S
 	if got.Uuid.String() != want.Uuid.String() {
 		t.Errorf("failed to match uuid on solutions: got '%s' want '%s'", got.Uuid.String(), want.Uuid.String())
 	}
+	if want.Status != "" {
+		assertStatusesAreEqual(t, got, want.Status)
+	}
 	if (want.Score == 1.0 && got.Score < 1.0) ||
 	    (want.Score < 1.0 && got.Score == 1.0) {
 		t.Errorf("failed to match score: got %f want %f", got.Score, want.Score)
@@ -124,6 +181,12 @@ func assertBigSolutionsAreEqual(t testing.TB, got *model.Solution, want *model.S
 	}
 }
 
+func assertStatusesAreEqual(t testing.TB, got *model.Solution, want model.SolutionStatus) {
+	if got.Status != want {
+		t.Errorf("failed to match status: got %s want %s", got.Status, want)
+	}
+}
+
 func assertVariablesAreEqual(t testing.TB, got *model.Solution, want *model.Solution) {
 	for _, wantVar := range want.Variables {
 		gotVar := findMatchingVariable(t, got, wantVar)
@@ -152,9 +215,11 @@ func singleClauseJob() *model.Job {
 		Name: u.NewString(),
 		Clauses: []*model.Clause{
 			{
-				Var1: &model.Variable{ Name: "v1", Negated: true },
-				Var2: &model.Variable{ Name: "v2", Negated: false },
-				Var3: &model.Variable{ Name: "v3", Negated: true },
+				Literals: []*model.Variable{
+					{ Name: "v1", Negated: true },
+					{ Name: "v2", Negated: false },
+					{ Name: "v3", Negated: true },
+				},
 			},
 		},
 	}
@@ -163,6 +228,7 @@ func singleClauseJob() *model.Job {
 func singleClauseSolution() *model.Solution {
 	return &model.Solution{
 		Score: 1.0,
+		Status: model.SolutionStatusSatisfiable,
 		Variables: []*model.SolvedVariable{
 			{ Name: "v1", Value: true },
 			{ Name: "v2", Value: true },
@@ -176,14 +242,18 @@ func twoClauseJob() *model.Job {
 		Name: u.NewString(),
 		Clauses: []*model.Clause{
 			{
-				Var1: &model.Variable{ Name: "v1", Negated: true },
-				Var2: &model.Variable{ Name: "v2", Negated: true },
-				Var3: &model.Variable{ Name: "v3", Negated: true },
+				Literals: []*model.Variable{
+					{ Name: "v1", Negated: true },
+					{ Name: "v2", Negated: true },
+					{ Name: "v3", Negated: true },
+				},
 			},
 			{
-				Var1: &model.Variable{ Name: "v1", Negated: false },
-				Var2: &model.Variable{ Name: "v2", Negated: false },
-				Var3: &model.Variable{ Name: "v3", Negated: false },
+				Literals: []*model.Variable{
+					{ Name: "v1", Negated: false },
+					{ Name: "v2", Negated: false },
+					{ Name: "v3", Negated: false },
+				},
 			},
 		},
 	}
@@ -192,6 +262,7 @@ func twoClauseJob() *model.Job {
 func twoClauseSolution() *model.Solution {
 	return &model.Solution{
 		Score: 1.0,
+		Status: model.SolutionStatusSatisfiable,
 		Variables: []*model.SolvedVariable{
 			{ Name: "v1", Value: false },
 			{ Name: "v2", Value: false },
@@ -207,9 +278,11 @@ func bigSolvableJob(random *rand.Rand) *model.Job {
 	}
 	for i := 0; i < 100; i++ {
 		job.Clauses = append(job.Clauses, &model.Clause{
-			Var1: &model.Variable{ Name: fmt.Sprintf("%d", i * 3 + 0), Negated: random.Intn(2) == 0 },
-			Var2: &model.Variable{ Name: fmt.Sprintf("%d", i * 3 + 1), Negated: random.Intn(2) == 0 },
-			Var3: &model.Variable{ Name: fmt.Sprintf("%d", i * 3 + 2), Negated: random.Intn(2) == 0 },
+			Literals: []*model.Variable{
+				{ Name: fmt.Sprintf("%d", i * 3 + 0), Negated: random.Intn(2) == 0 },
+				{ Name: fmt.Sprintf("%d", i * 3 + 1), Negated: random.Intn(2) == 0 },
+				{ Name: fmt.Sprintf("%d", i * 3 + 2), Negated: random.Intn(2) == 0 },
+			},
 		})
 	}
 	return job
@@ -218,6 +291,7 @@ func bigSolvableJob(random *rand.Rand) *model.Job {
 func bigSolvableSolution() *model.Solution {
 	solution := &model.Solution{
 		Score: 1.0,
+		Status: model.SolutionStatusSatisfiable,
 		Variables: []*model.SolvedVariable{},
 	}
 	for i := 0; i < 300; i++ {
@@ -233,21 +307,27 @@ func bigUnsolvableJob(random *rand.Rand) *model.Job {
 	job := &model.Job{
 		Name: u.NewString(),
 		Clauses: []*model.Clause{{
-				Var1: &model.Variable{ Name: "1000", Negated: true },
-				Var2: &model.Variable{ Name: "1000", Negated: true },
-				Var3: &model.Variable{ Name: "1000", Negated: true },
+				Literals: []*model.Variable{
+					{ Name: "1000", Negated: true },
+					{ Name: "1000", Negated: true },
+					{ Name: "1000", Negated: true },
+				},
 			},{
-				Var1: &model.Variable{ Name: "1000", Negated: false },
-				Var2: &model.Variable{ Name: "1000", Negated: false },
-				Var3: &model.Variable{ Name: "1000", Negated: false },
+				Literals: []*model.Variable{
+					{ Name: "1000", Negated: false },
+					{ Name: "1000", Negated: false },
+					{ Name: "1000", Negated: false },
+				},
 			},
 		},
 	}
 	for i := 0; i < 100; i++ {
 		job.Clauses = append(job.Clauses, &model.Clause{
-			Var1: &model.Variable{ Name: fmt.Sprintf("%d", i * 3 + 0), Negated: random.Intn(2) == 0 },
-			Var2: &model.Variable{ Name: fmt.Sprintf("%d", i * 3 + 1), Negated: random.Intn(2) == 0 },
-			Var3: &model.Variable{ Name: fmt.Sprintf("%d", i * 3 + 2), Negated: random.Intn(2) == 0 },
+			Literals: []*model.Variable{
+				{ Name: fmt.Sprintf("%d", i * 3 + 0), Negated: random.Intn(2) == 0 },
+				{ Name: fmt.Sprintf("%d", i * 3 + 1), Negated: random.Intn(2) == 0 },
+				{ Name: fmt.Sprintf("%d", i * 3 + 2), Negated: random.Intn(2) == 0 },
+			},
 		})
 	}
 	return job
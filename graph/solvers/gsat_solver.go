@@ -0,0 +1,74 @@
+package solvers
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+type gsatSolver struct {
+	maxTries int
+	maxFlips int
+	noise float64
+	maxTime time.Duration
+	solutionFactory *factories.SolutionFactory
+	randomFactory factories.RandomFactory
+}
+
+func NewGsatSolver(
+	maxTries int,
+	maxFlips int,
+	noise float64,
+	maxTime time.Duration,
+	solutionFactory *factories.SolutionFactory,
+	randomFactory factories.RandomFactory,
+) *gsatSolver {
+	return &gsatSolver{
+		maxTries: maxTries,
+		maxFlips: maxFlips,
+		noise: noise,
+		maxTime: maxTime,
+		solutionFactory: solutionFactory,
+		randomFactory: randomFactory,
+	}
+}
+
+func (s *gsatSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, s.maxTime)
+	defer cancel()
+	random := s.randomFactory.Build()
+	search := newLocalSearch(job.Formula())
+	history := newHistoryRecorder(progressInterval)
+	best, flips, status := search.run(ctx, s.maxTries, s.maxFlips, random, s.pickVariable, history)
+	solution := s.solutionFactory.ConstructSolution(best, job, flips, time.Since(start), status)
+	solution.History = history.points
+	return solution
+}
+
+// pickVariable performs a random walk step on an unsatisfied clause with
+// probability noise, otherwise it greedily picks the variable whose flip
+// gives the largest net gain in satisfied clauses, breaking ties randomly.
+func (s *gsatSolver) pickVariable(search *localSearch, random *rand.Rand) int {
+	if random.Float64() < s.noise {
+		clause := search.randomUnsatisfiedClause(random)
+		variable, _ := decodeLiteral(clause[random.Intn(len(clause))])
+		return variable
+	}
+	candidates := []int{}
+	bestGain := 0
+	for variable := range search.values {
+		gain := search.makeCounts[variable] - search.breakCounts[variable]
+		if len(candidates) == 0 || gain > bestGain {
+			candidates = candidates[:0]
+			bestGain = gain
+		}
+		if gain == bestGain {
+			candidates = append(candidates, variable)
+		}
+	}
+	return candidates[random.Intn(len(candidates))]
+}
@@ -0,0 +1,44 @@
+package solvers
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestGsatSolve(t *testing.T) {
+	cases := []struct {
+		desc string
+		job *model.Job
+		want *model.Solution
+	}{
+		{ "single clause is fully solved", singleClauseJob(), singleClauseSolution() },
+		{ "two clauses are fully solved", twoClauseJob(), twoClauseSolution() },
+		{ "big solvable job is fully solved", bigSolvableJob(rand.New(rand.NewSource(0))), bigSolvableSolution() },
+		{ "big unsolvable job gives up", bigUnsolvableJob(rand.New(rand.NewSource(0))), withStatus(bigUnsolvableSolution(), model.SolutionStatusUnknown) },
+		{ "planted job is fully solved", plantedJob(rand.New(rand.NewSource(4)), 200, 700), &model.Solution{
+				Score: 1.0,
+				Status: model.SolutionStatusSatisfiable,
+				Variables: make([]*model.SolvedVariable, 200),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			tc.want.Uuid = tc.job.Uuid
+			maxTime, _ := time.ParseDuration("10s")
+			sut := NewGsatSolver(10, 10000, 0.3, maxTime, &factories.SolutionFactory{}, &factories.ZeroRandomFactory{})
+
+			// act
+			got := sut.Solve(context.Background(), tc.job)
+
+			// assert
+			assertBigSolutionsAreEqual(t, got, tc.want)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package solvers
+
+import (
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+// maxHistory bounds the number of points kept in the history of a solve.
+const maxHistory = 1000
+
+// historyRecorder keeps the convergence history of a solve, recording every
+// step cycles. Once maxHistory points are kept it drops every other one and
+// doubles step, so a long solve is traced evenly at a bounded size.
+type historyRecorder struct {
+	points []*model.HistoryPoint
+	step int
+}
+
+func newHistoryRecorder(step int) *historyRecorder {
+	return &historyRecorder{
+		points: []*model.HistoryPoint{},
+		step: step,
+	}
+}
+
+func (h *historyRecorder) due(cycle int) bool {
+	return cycle % h.step == 0
+}
+
+// record adds a point for a cycle that was due.
+func (h *historyRecorder) record(point *model.HistoryPoint) {
+	if len(h.points) >= maxHistory {
+		h.step *= 2
+		kept := h.points[:0]
+		for _, existing := range h.points {
+			if h.due(existing.Cycle) {
+				kept = append(kept, existing)
+			}
+		}
+		h.points = kept
+		if !h.due(point.Cycle) {
+			return
+		}
+	}
+	h.points = append(h.points, point)
+}
+
+// finish records the point the solve ended on unless it already has been.
+func (h *historyRecorder) finish(point *model.HistoryPoint) []*model.HistoryPoint {
+	if len(h.points) == 0 || h.points[len(h.points) - 1].Cycle != point.Cycle {
+		h.points = append(h.points, point)
+	}
+	return h.points
+}
@@ -0,0 +1,75 @@
+package solvers
+
+import (
+	"testing"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestHistoryRecorder(t *testing.T) {
+	cases := []struct {
+		desc string
+		step int
+		cycles int
+		wantPoints int
+		wantGap int
+	}{
+		{ "keeps every due cycle while there is room", 1, 500, 501, 1 },
+		{ "thins out once full", 1, 5000, 626, 8 },
+		{ "thins out from a larger step", 1024, 1024 * 3000, 751, 4096 },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := newHistoryRecorder(tc.step)
+
+			// act
+			for cycle := 0; cycle < tc.cycles; cycle += tc.step {
+				if sut.due(cycle) {
+					sut.record(&model.HistoryPoint{Cycle: cycle})
+				}
+			}
+			got := sut.finish(&model.HistoryPoint{Cycle: tc.cycles})
+
+			// assert
+			if len(got) != tc.wantPoints {
+				t.Fatalf("wrong number of points: got %d want %d", len(got), tc.wantPoints)
+			}
+			if len(got) > maxHistory + 1 {
+				t.Errorf("kept too many points: got %d want at most %d", len(got), maxHistory + 1)
+			}
+			for index := 1; index < len(got) - 1; index++ {
+				if gap := got[index].Cycle - got[index - 1].Cycle; gap != tc.wantGap {
+					t.Fatalf("uneven points at %d: got gap %d want %d", index, gap, tc.wantGap)
+				}
+			}
+			if got[len(got) - 1].Cycle != tc.cycles {
+				t.Errorf("wrong last cycle: got %d want %d", got[len(got) - 1].Cycle, tc.cycles)
+			}
+		})
+	}
+}
+
+func TestPopulationDiversity(t *testing.T) {
+	cases := []struct {
+		desc string
+		population population
+		want float64
+	}{
+		{ "identical members", population{ memberOf(true, false), memberOf(true, false) }, 0.0 },
+		{ "complementary members", population{ memberOf(true, false), memberOf(false, true) }, 1.0 },
+		{ "one differing variable", population{ memberOf(true, false), memberOf(true, true) }, 0.5 },
+		{ "single member", population{ memberOf(true, false) }, 0.0 },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// act
+			got := tc.population.diversity(2)
+
+			// assert
+			if got != tc.want {
+				t.Errorf("wrong diversity: got %f want %f", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package solvers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+// islandSolver runs the genetic algorithm on several islands at once, at most
+// threads of them at a time. Every island evolves its own population for
+// migrationInterval generations, then the islands wait for each other and
+// each sends copies of its best migrants members to the next island around
+// the ring, where they replace the worst members. Islands draw from random
+// sources seeded in turn from the solver's, and they only meet at
+// migrations, so a seed gives the same result however the islands are
+// scheduled, short of a timeout.
+type islandSolver struct {
+	genetic *geneticSolver
+	islands int
+	migrationInterval int
+	migrants int
+	threads int
+}
+
+func NewIslandSolver(
+	genetic *geneticSolver,
+	islands int,
+	migrationInterval int,
+	migrants int,
+	threads int,
+) *islandSolver {
+	return &islandSolver{
+		genetic: genetic,
+		islands: islands,
+		migrationInterval: migrationInterval,
+		migrants: migrants,
+		threads: threads,
+	}
+}
+
+func (s *islandSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, s.genetic.maxTime)
+	defer cancel()
+	random := s.genetic.randomFactory.Build()
+	table := newClauseTable(job)
+	islands, generateErr := s.newIslands(ctx, table, random)
+	history := newHistoryRecorder(1)
+	var bestMember member
+	var cycles int
+	var status model.SolutionStatus
+	var err error
+	if generateErr != nil {
+		bestMember, status = s.genetic.interrupted(ctx, table, bestIsland(islands), history)
+	} else if len(islands[0].population) < s.genetic.maxPopulation {
+		// every island already holds every possible assignment
+		bestMember, cycles, status = s.genetic.start(ctx, table, islands[0], history)
+	} else {
+		bestMember, cycles, status, err = s.evolve(ctx, table, islands, history)
+	}
+	elapsed := time.Since(start)
+	if err != nil {
+		log.Printf("island solver failed on job %s: %v", job.Uuid.String(), err)
+		return s.genetic.solutionFactory.ConstructEmptySolution(job, elapsed, model.SolutionStatusError)
+	}
+	solution := s.genetic.solutionFactory.ConstructSolution(table.assignment(bestMember), job, cycles, elapsed, status)
+	solution.History = history.points
+	return solution
+}
+
+// newIslands generates the population of every island, unless ctx ends
+// first, in which case it returns the islands generated so far along with the
+// context's error.
+func (s *islandSolver) newIslands(ctx context.Context, table *clauseTable, random *rand.Rand) ([]*island, error) {
+	islands := []*island{}
+	for index := 0; index < s.islands; index++ {
+		islandRandom := rand.New(rand.NewSource(random.Int63()))
+		population, err := s.genetic.populationGenerator.generatePopulationFrom(ctx, s.genetic.maxPopulation, table.size(), islandRandom)
+		islands = append(islands, s.genetic.newIsland(table, population, islandRandom))
+		if err != nil {
+			return islands, err
+		}
+	}
+	return islands, nil
+}
+
+// evolve reports progress and traces history at migrations only, since the
+// islands run concurrently in between. It gives up with an error as soon as
+// an island fails.
+func (s *islandSolver) evolve(ctx context.Context, table *clauseTable, islands []*island, history *historyRecorder) (member, int, model.SolutionStatus, error) {
+	reporter := progressReporter(ctx)
+	generations := 0
+	best := bestIsland(islands)
+	reporter.Report(scoredProgress(generations, best.bestScore, table.assignment(best.bestMember)))
+	history.record(s.historyPoint(table, islands, generations, best.bestScore))
+	for ctx.Err() == nil && !best.solved() && s.genetic.generationsLeft(generations) {
+		target := generations + s.migrationInterval
+		if !s.genetic.generationsLeft(target) {
+			target = s.genetic.maxGenerations
+		}
+		err := s.evolveIslands(ctx, table, islands, target)
+		if err != nil {
+			return nil, generations, model.SolutionStatusError, err
+		}
+		previousScore := best.bestScore
+		best = bestIsland(islands)
+		generations = mostGenerations(islands)
+		if ctx.Err() != nil {
+			break
+		}
+		var improved map[string]bool
+		if best.bestScore > previousScore {
+			improved = table.assignment(best.bestMember)
+		}
+		reporter.Report(scoredProgress(generations, best.bestScore, improved))
+		if history.due(generations) {
+			history.record(s.historyPoint(table, islands, generations, best.bestScore))
+		}
+		if !best.solved() {
+			s.migrate(table, islands)
+		}
+	}
+	history.finish(s.historyPoint(table, islands, generations, best.bestScore))
+	return best.bestMember, generations, s.genetic.status(ctx, best.bestMember, table), nil
+}
+
+// evolveIslands evolves every island up to generation target, or until it
+// solves the job, on at most threads goroutines. A panic on one of them is
+// recovered, since it would take the server down, and returned as an error
+// once the other goroutines are done.
+func (s *islandSolver) evolveIslands(ctx context.Context, table *clauseTable, islands []*island, target int) error {
+	pending := make(chan *island, len(islands))
+	for _, island := range islands {
+		pending <- island
+	}
+	close(pending)
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var failure error
+	for thread := 0; thread < s.threads && thread < len(islands); thread++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					mutex.Lock()
+					failure = fmt.Errorf("island failed: %v", r)
+					mutex.Unlock()
+				}
+			}()
+			for island := range pending {
+				for ctx.Err() == nil && !island.solved() && island.generations < target {
+					s.genetic.generation(ctx, table, island)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return failure
+}
+
+// bestIsland returns the first island holding the best score.
+func bestIsland(islands []*island) *island {
+	best := islands[0]
+	for _, island := range islands {
+		if island.bestScore > best.bestScore {
+			best = island
+		}
+	}
+	return best
+}
+
+func mostGenerations(islands []*island) int {
+	generations := 0
+	for _, island := range islands {
+		if island.generations > generations {
+			generations = island.generations
+		}
+	}
+	return generations
+}
+
+// migrate picks every island's emigrants before any island takes them in, so
+// the order of the islands doesn't matter.
+func (s *islandSolver) migrate(table *clauseTable, islands []*island) {
+	if len(islands) < 2 {
+		return
+	}
+	emigrants := make([]population, len(islands))
+	for index, island := range islands {
+		emigrants[index] = island.emigrants(table, s.migrants)
+	}
+	for index, island := range islands {
+		island.immigrate(table, emigrants[(index + len(islands) - 1) % len(islands)])
+	}
+}
+
+// emigrants returns copies of the count best members of the island, leaving
+// at least one member out so an island can't be flooded entirely.
+func (i *island) emigrants(table *clauseTable, count int) population {
+	if count > len(i.population) - 1 {
+		count = len(i.population) - 1
+	}
+	order := orderByScore(i.population.scores(table))
+	emigrants := population{}
+	for rank := len(order) - 1; rank >= len(order) - count; rank-- {
+		emigrants = append(emigrants, i.population[order[rank]].clone())
+	}
+	return emigrants
+}
+
+// immigrate replaces the worst members of the island with the immigrants it
+// doesn't hold yet.
+func (i *island) immigrate(table *clauseTable, immigrants population) {
+	order := orderByScore(i.population.scores(table))
+	replaced := 0
+	for _, immigrant := range immigrants {
+		if !i.population.memberExists(immigrant) {
+			i.population[order[replaced]] = immigrant
+			replaced++
+		}
+	}
+	i.bestMember, i.bestScore = i.population.best(table)
+}
+
+func (s *islandSolver) historyPoint(table *clauseTable, islands []*island, generations int, bestScore float64) *model.HistoryPoint {
+	combined := population{}
+	for _, island := range islands {
+		combined = append(combined, island.population...)
+	}
+	return s.genetic.historyPoint(table, combined, generations, bestScore)
+}
@@ -0,0 +1,170 @@
+package solvers
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestIslandSolve(t *testing.T) {
+	maxTime, _ := time.ParseDuration("10s")
+	factory := &factories.SolutionFactory{}
+	cases := []struct {
+		desc string
+		job *model.Job
+		want model.SolutionStatus
+	}{
+		{ "planted job is solved", plantedJob(rand.New(rand.NewSource(0)), 30, 120), model.SolutionStatusSatisfiable },
+		{ "every sign combination is proved unsatisfiable", everyCombinationJob(), model.SolutionStatusUnsatisfiable },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			randomFactory := &factories.SeededRandomFactory{Seed: 1}
+			sut := NewIslandSolver(NewGeneticSolver(20, maxTime, 0, 0.05, GeneticOperators{}, factory, NewPopulationGenerator(randomFactory), randomFactory), 4, 20, 2, 4)
+
+			// act
+			got := sut.Solve(context.Background(), tc.job)
+
+			// assert
+			assertStatusesAreEqual(t, got, tc.want)
+		})
+	}
+}
+
+func TestIslandSolveIsDeterministic(t *testing.T) {
+	// arrange
+	maxTime, _ := time.ParseDuration("10s")
+	factory := &factories.SolutionFactory{}
+	job := randomJob(rand.New(rand.NewSource(0)), 100, 426)
+	solve := func(threads int) *model.Solution {
+		randomFactory := &factories.SeededRandomFactory{Seed: 7}
+		genetic := NewGeneticSolver(20, maxTime, 200, 0.01, GeneticOperators{ Mutation: model.MutationOperatorAdaptive }, factory, NewPopulationGenerator(randomFactory), randomFactory)
+		return NewIslandSolver(genetic, 4, 25, 2, threads).Solve(context.Background(), job)
+	}
+
+	// act
+	want := solve(1)
+	got := solve(4)
+
+	// assert
+	if got.Score != want.Score || got.Cycles != want.Cycles || got.Status != want.Status {
+		t.Fatalf("got (%f %d %s) want (%f %d %s)", got.Score, got.Cycles, got.Status, want.Score, want.Cycles, want.Status)
+	}
+	wantVariables := map[string]bool{}
+	for _, variable := range want.Variables {
+		wantVariables[variable.Name] = variable.Value
+	}
+	for _, variable := range got.Variables {
+		if wantVariables[variable.Name] != variable.Value {
+			t.Errorf("wrong value for %s: got %t want %t", variable.Name, variable.Value, wantVariables[variable.Name])
+		}
+	}
+	if len(got.History) != len(want.History) {
+		t.Fatalf("wrong number of history points: got %d want %d", len(got.History), len(want.History))
+	}
+	for index, point := range want.History {
+		if got.History[index].BestScore != point.BestScore || *got.History[index].AverageScore != *point.AverageScore {
+			t.Errorf("history point %d got %+v want %+v", index, *got.History[index], *point)
+		}
+	}
+}
+
+func TestSolveStopsAtMaxGenerations(t *testing.T) {
+	maxTime, _ := time.ParseDuration("10s")
+	factory := &factories.SolutionFactory{}
+	randomFactory := &factories.ZeroRandomFactory{}
+	cases := []struct {
+		desc string
+		sut Solver
+	}{
+		{ "genetic solver", NewGeneticSolver(10, maxTime, 30, 0.01, GeneticOperators{}, factory, NewPopulationGenerator(randomFactory), randomFactory) },
+		{ "island solver", NewIslandSolver(NewGeneticSolver(10, maxTime, 30, 0.01, GeneticOperators{}, factory, NewPopulationGenerator(randomFactory), randomFactory), 3, 20, 2, 3) },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			job := randomJob(rand.New(rand.NewSource(0)), 300, 1400)
+
+			// act
+			got := tc.sut.Solve(context.Background(), job)
+
+			// assert
+			assertStatusesAreEqual(t, got, model.SolutionStatusUnknown)
+			if got.Cycles != 30 {
+				t.Errorf("wrong number of generations: got %d want 30", got.Cycles)
+			}
+		})
+	}
+}
+
+type panickingMutation struct {
+}
+
+func (m *panickingMutation) mutate(child member, table *clauseTable, random *rand.Rand) member {
+	panic("mutation failure")
+}
+
+func (m *panickingMutation) adapt(improved bool) {
+}
+
+func TestEvolveFailsWhenAnIslandPanics(t *testing.T) {
+	// arrange
+	maxTime, _ := time.ParseDuration("10s")
+	randomFactory := &factories.SeededRandomFactory{Seed: 1}
+	sut := NewIslandSolver(NewGeneticSolver(10, maxTime, 0, 0.01, GeneticOperators{}, &factories.SolutionFactory{}, NewPopulationGenerator(randomFactory), randomFactory), 4, 20, 2, 2)
+	table := newClauseTable(randomJob(rand.New(rand.NewSource(0)), 100, 426))
+	islands, err := sut.newIslands(context.Background(), table, randomFactory.Build())
+	if err != nil {
+		t.Fatalf("failed to generate islands: %v", err)
+	}
+	islands[2].operators.mutation = &panickingMutation{}
+
+	// act
+	_, _, status, err := sut.evolve(context.Background(), table, islands, newHistoryRecorder(1))
+
+	// assert
+	if err == nil {
+		t.Fatalf("failed to return the panic as an error")
+	}
+	if status != model.SolutionStatusError {
+		t.Errorf("wrong status: got %s want %s", status, model.SolutionStatusError)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	// arrange
+	table := newClauseTable(unitClausesJob("v1", "v2", "v3"))
+	sut := &islandSolver{migrants: 1}
+	best := memberOf(true, true, true)
+	islands := []*island{
+		{ population: population{ memberOf(false, false, false), best, memberOf(true, false, false) } },
+		{ population: population{ memberOf(false, true, false), memberOf(false, false, true), memberOf(true, true, false) } },
+		{ population: population{ memberOf(false, true, true), best, memberOf(true, false, true) } },
+	}
+
+	// act
+	sut.migrate(table, islands)
+
+	// assert
+	assertPopulationsAreEqual(t, islands[0].population, population{ memberOf(false, false, false), best, memberOf(true, false, false) })
+	assertPopulationsAreEqual(t, islands[1].population, population{ best, memberOf(false, false, true), memberOf(true, true, false) })
+	assertPopulationsAreEqual(t, islands[2].population, population{ memberOf(true, true, false), best, memberOf(true, false, true) })
+	for index, island := range islands {
+		if !island.bestMember.matches(best) || island.bestScore != 1.0 {
+			t.Errorf("island %d has the wrong best member: got %v scoring %f", index, island.bestMember, island.bestScore)
+		}
+	}
+}
+
+func unitClausesJob(names ...string) *model.Job {
+	job := &model.Job{ Clauses: []*model.Clause{} }
+	for _, name := range names {
+		job.Clauses = append(job.Clauses, &model.Clause{ Literals: []*model.Variable{ { Name: name } } })
+	}
+	return job
+}
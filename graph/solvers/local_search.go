@@ -0,0 +1,199 @@
+package solvers
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+// localSearch keeps a complete assignment together with the bookkeeping that
+// stochastic local search needs to flip variables cheaply: the number of true
+// literals per clause, the list of unsatisfied clauses and, per variable, how
+// many clauses a flip would make or break.
+type localSearch struct {
+	formula *model.Formula
+	clauses [][]int
+	occurrences [][]int
+	values []bool
+	trueCounts []int
+	unsatisfied []int
+	unsatisfiedIndices []int
+	makeCounts []int
+	breakCounts []int
+}
+
+func newLocalSearch(formula *model.Formula) *localSearch {
+	variables := len(formula.Names)
+	return &localSearch{
+		formula: formula,
+		clauses: formula.Clauses,
+		occurrences: formula.Occurrences,
+		values: make([]bool, variables),
+		trueCounts: make([]int, len(formula.Clauses)),
+		unsatisfiedIndices: make([]int, len(formula.Clauses)),
+		makeCounts: make([]int, variables),
+		breakCounts: make([]int, variables),
+	}
+}
+
+func (l *localSearch) randomize(random *rand.Rand) {
+	for variable := range l.values {
+		l.values[variable] = random.Intn(2) == 1
+	}
+	l.reset()
+}
+
+func (l *localSearch) reset() {
+	l.unsatisfied = []int{}
+	for variable := range l.values {
+		l.makeCounts[variable] = 0
+		l.breakCounts[variable] = 0
+	}
+	for clause := range l.clauses {
+		l.trueCounts[clause] = l.countTrue(clause)
+		l.unsatisfiedIndices[clause] = -1
+		if l.trueCounts[clause] == 0 {
+			l.markUnsatisfied(clause)
+		}
+		l.contribute(clause, 1)
+	}
+}
+
+func (l *localSearch) flip(variable int) {
+	for _, clause := range l.occurrences[variable] {
+		l.contribute(clause, -1)
+	}
+	l.values[variable] = !l.values[variable]
+	for _, clause := range l.occurrences[variable] {
+		before := l.trueCounts[clause]
+		l.trueCounts[clause] = l.countTrue(clause)
+		if before == 0 && l.trueCounts[clause] > 0 {
+			l.markSatisfied(clause)
+		} else if before > 0 && l.trueCounts[clause] == 0 {
+			l.markUnsatisfied(clause)
+		}
+		l.contribute(clause, 1)
+	}
+}
+
+// contribute adds (or with sign -1 removes) the make and break counts that a
+// clause currently contributes to its variables.
+func (l *localSearch) contribute(clause int, sign int) {
+	switch l.trueCounts[clause] {
+	case 0:
+		for _, literal := range l.clauses[clause] {
+			variable, _ := decodeLiteral(literal)
+			l.makeCounts[variable] += sign
+		}
+	case 1:
+		for _, literal := range l.clauses[clause] {
+			if l.literalTrue(literal) {
+				variable, _ := decodeLiteral(literal)
+				l.breakCounts[variable] += sign
+			}
+		}
+	}
+}
+
+func (l *localSearch) countTrue(clause int) int {
+	count := 0
+	for _, literal := range l.clauses[clause] {
+		if l.literalTrue(literal) {
+			count++
+		}
+	}
+	return count
+}
+
+func (l *localSearch) literalTrue(literal int) bool {
+	variable, value := decodeLiteral(literal)
+	return l.values[variable] == (value == 1)
+}
+
+func (l *localSearch) markUnsatisfied(clause int) {
+	l.unsatisfiedIndices[clause] = len(l.unsatisfied)
+	l.unsatisfied = append(l.unsatisfied, clause)
+}
+
+func (l *localSearch) markSatisfied(clause int) {
+	index := l.unsatisfiedIndices[clause]
+	last := l.unsatisfied[len(l.unsatisfied) - 1]
+	l.unsatisfied[index] = last
+	l.unsatisfiedIndices[last] = index
+	l.unsatisfied = l.unsatisfied[:len(l.unsatisfied) - 1]
+	l.unsatisfiedIndices[clause] = -1
+}
+
+func (l *localSearch) randomUnsatisfiedClause(random *rand.Rand) []int {
+	return l.clauses[l.unsatisfied[random.Intn(len(l.unsatisfied))]]
+}
+
+func (l *localSearch) solved() bool {
+	return len(l.unsatisfied) == 0
+}
+
+// score matches Job.Score for an assignment leaving unsatisfied clauses
+// unsatisfied.
+func (l *localSearch) score(unsatisfied int) float64 {
+	return l.formula.ScoreSatisfied(l.formula.ClauseCount() - unsatisfied)
+}
+
+// run restarts from a random assignment up to maxTries times, flipping the
+// variable chosen by pick up to maxFlips times per try, and returns the
+// assignment with the fewest unsatisfied clauses along with the total flips.
+// Local search can never prove a job unsatisfiable, so running out of tries
+// is reported as unknown. The best score is traced in history.
+func (l *localSearch) run(
+	ctx context.Context,
+	maxTries int,
+	maxFlips int,
+	random *rand.Rand,
+	pick func(*localSearch, *rand.Rand) int,
+	history *historyRecorder,
+) (map[string]bool, int, model.SolutionStatus) {
+	status := model.SolutionStatusUnknown
+	flips := 0
+	reporter := progressReporter(ctx)
+	best := make([]bool, len(l.values))
+	bestUnsatisfied := -1
+	reportedUnsatisfied := -1
+search:
+	for try := 0; try < maxTries; try++ {
+		l.randomize(random)
+		for flip := 0; ; flip++ {
+			if bestUnsatisfied < 0 || len(l.unsatisfied) < bestUnsatisfied {
+				copy(best, l.values)
+				bestUnsatisfied = len(l.unsatisfied)
+			}
+			if l.solved() || flip >= maxFlips {
+				break
+			}
+			if flips % progressInterval == 0 {
+				if ctx.Err() != nil {
+					status = interruptedStatus(ctx)
+					break search
+				}
+				var improved map[string]bool
+				if reportedUnsatisfied < 0 || bestUnsatisfied < reportedUnsatisfied {
+					improved = l.formula.Assignment(best)
+					reportedUnsatisfied = bestUnsatisfied
+				}
+				reporter.Report(scoredProgress(flips, l.score(bestUnsatisfied), improved))
+				if history.due(flips) {
+					history.record(&model.HistoryPoint{Cycle: flips, BestScore: l.score(bestUnsatisfied)})
+				}
+			}
+			l.flip(pick(l, random))
+			flips++
+		}
+		if l.solved() {
+			status = model.SolutionStatusSatisfiable
+			break
+		}
+	}
+	if bestUnsatisfied >= 0 {
+		history.finish(&model.HistoryPoint{Cycle: flips, BestScore: l.score(bestUnsatisfied)})
+	}
+	return l.formula.Assignment(best), flips, status
+}
@@ -0,0 +1,57 @@
+package solvers
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestLocalSearchFlipKeepsCountsConsistent(t *testing.T) {
+	cases := []struct {
+		desc string
+		search *localSearch
+	}{
+		{ "random job", newLocalSearch(randomJob(rand.New(rand.NewSource(0)), 20, 90).Formula()) },
+		{ "every sign combination", newLocalSearch(everyCombinationJob().Formula()) },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			random := rand.New(rand.NewSource(0))
+			tc.search.randomize(random)
+
+			// act
+			for i := 0; i < 500; i++ {
+				tc.search.flip(random.Intn(len(tc.search.values)))
+			}
+
+			// assert
+			want := &localSearch{
+				clauses: tc.search.clauses,
+				values: tc.search.values,
+				trueCounts: make([]int, len(tc.search.clauses)),
+				unsatisfiedIndices: make([]int, len(tc.search.clauses)),
+				makeCounts: make([]int, len(tc.search.values)),
+				breakCounts: make([]int, len(tc.search.values)),
+			}
+			want.reset()
+			assertLocalSearchesAreEqual(t, tc.search, want)
+		})
+	}
+}
+
+func assertLocalSearchesAreEqual(t testing.TB, got *localSearch, want *localSearch) {
+	if len(got.unsatisfied) != len(want.unsatisfied) {
+		t.Errorf("wrong number of unsatisfied clauses: got %d want %d", len(got.unsatisfied), len(want.unsatisfied))
+	}
+	for _, clause := range got.unsatisfied {
+		if want.trueCounts[clause] != 0 {
+			t.Errorf("clause %d is listed as unsatisfied but has %d true literals", clause, want.trueCounts[clause])
+		}
+	}
+	for variable := range got.values {
+		if got.makeCounts[variable] != want.makeCounts[variable] || got.breakCounts[variable] != want.breakCounts[variable] {
+			t.Errorf("wrong counts for variable %d: got (%d %d) want (%d %d)", variable,
+				got.makeCounts[variable], got.breakCounts[variable], want.makeCounts[variable], want.breakCounts[variable])
+		}
+	}
+}
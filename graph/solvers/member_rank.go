@@ -1,6 +1,9 @@
 package solvers
 
-import "math/rand"
+import (
+	"math/rand"
+	"sort"
+)
 
 type memberRank struct {
 	index   int
@@ -21,6 +24,25 @@ func (m memberRanks) Swap(i, j int) {
 	m[i], m[j] = m[j], m[i]
 }
 
+// newMemberRanks sorts members by weight, best first, and turns the weights
+// into cumulative fractions of their total, so selectMember draws each member
+// in proportion to its weight.
+func newMemberRanks(weights []float64) memberRanks {
+	ranks := memberRanks{}
+	total := 0.0
+	for index, weight := range weights {
+		ranks = append(ranks, memberRank{index: index, fitness: weight})
+		total += weight
+	}
+	sort.Sort(sort.Reverse(ranks))
+	normTotal := 0.0
+	for index, rank := range ranks {
+		normTotal += rank.fitness / total
+		ranks[index].fitness = normTotal
+	}
+	return ranks
+}
+
 func (m memberRanks) selectMember(random *rand.Rand) int {
 	target := random.Float64()
 	for _, rank := range m {
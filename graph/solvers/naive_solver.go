@@ -1,6 +1,7 @@
 package solvers
 
 import (
+	"context"
 	"time"
 
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
@@ -19,13 +20,18 @@ func NewNaiveSolver(
 	}
 }
 
-func (s *naiveSolver) Solve(job *model.Job) *model.Solution {
+func (s *naiveSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
 	start := time.Now()
-	variables := map[string]bool{}
-	for _, c := range job.Clauses {
-		variables[c.Var1.Name] = true
-		variables[c.Var2.Name] = true
-		variables[c.Var3.Name] = true
+	formula := job.Formula()
+	values := make([]bool, len(formula.Names))
+	for index := range values {
+		values[index] = true
 	}
-	return s.solutionFactory.ConstructSolution(variables, job, 0, time.Since(start))
+	status := model.SolutionStatusUnknown
+	if ctx.Err() != nil {
+		status = interruptedStatus(ctx)
+	} else if formula.Score(values) == 1.0 {
+		status = model.SolutionStatusSatisfiable
+	}
+	return s.solutionFactory.ConstructSolution(formula.Assignment(values), job, 0, time.Since(start), status)
 }
@@ -1,16 +1,22 @@
 package solvers
 
 import (
+	"encoding/binary"
+	"math"
 	"math/rand"
 	"sort"
-
-	"github.com/samber/lo"
-	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
 )
 
-type member map[string]bool
+// member is an assignment packed 64 variables to a word, variable i being bit
+// i%64 of word i/64. Bits past the last variable stay zero, so members can be
+// compared word by word.
+type member []uint64
 type population []member
 
+func newMember(size int) member {
+	return make(member, (size + 63) / 64)
+}
+
 func (p population) memberExists(newMember member) bool {
 	for _, member := range p {
 		if newMember.matches(member) {
@@ -20,11 +26,31 @@ func (p population) memberExists(newMember member) bool {
 	return false
 }
 
-func (p population) best(job *model.Job) (member, float64) {
+// memberSet holds members by their words, so telling whether a population
+// already holds a member doesn't take a scan of the whole population.
+type memberSet map[string]bool
+
+func newMemberSet(p population) memberSet {
+	set := memberSet{}
+	for _, member := range p {
+		set.add(member)
+	}
+	return set
+}
+
+func (s memberSet) add(m member) {
+	s[m.key()] = true
+}
+
+func (s memberSet) contains(m member) bool {
+	return s[m.key()]
+}
+
+func (p population) best(table *clauseTable) (member, float64) {
 	bestScore := 0.0
 	bestMember := p[0]
 	for _, member := range p {
-		score := job.Score(member)
+		score := table.score(member)
 		if score > bestScore {
 			bestScore = score
 			bestMember = member
@@ -33,54 +59,160 @@ func (p population) best(job *model.Job) (member, float64) {
 	return bestMember, bestScore
 }
 
-func (p population) rank(job *model.Job) memberRanks {
-	ranks := memberRanks{}
+func (p population) averageScore(table *clauseTable) float64 {
+	total := 0.0
+	for _, member := range p {
+		total += table.score(member)
+	}
+	return total / float64(len(p))
+}
+
+// diversity is the mean fraction of variables on which two distinct members
+// differ. With c of n members setting a variable, 2c(n-c) of the n(n-1)
+// ordered pairs disagree on it, so no pairs need to be compared.
+func (p population) diversity(size int) float64 {
+	if len(p) < 2 || size == 0 {
+		return 0.0
+	}
+	n := float64(len(p))
 	total := 0.0
+	for variable := 0; variable < size; variable++ {
+		c := 0.0
+		for _, member := range p {
+			if member.get(variable) {
+				c++
+			}
+		}
+		total += 2 * c * (n - c) / (n * (n - 1))
+	}
+	return total / float64(size)
+}
+
+func (p population) scores(table *clauseTable) []float64 {
+	scores := make([]float64, len(p))
 	for index, member := range p {
-		score := job.Score(member)
-		ranks = append(ranks, memberRank{index: index, fitness: score})
-		total += score
+		scores[index] = table.score(member)
+	}
+	return scores
+}
+
+// orderByScore returns the indices of the members from the lowest score to
+// the highest, keeping members with equal scores in index order.
+func orderByScore(scores []float64) []int {
+	order := make([]int, len(scores))
+	for index := range order {
+		order[index] = index
 	}
-	sort.Sort(sort.Reverse(ranks))
-	normTotal := 0.0
-	for index, rank := range ranks {
-		normTotal += rank.fitness / total
-		ranks[index].fitness = normTotal
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] < scores[order[j]]
+	})
+	return order
+}
+
+func (m member) get(variable int) bool {
+	return m[variable >> 6] & (1 << (variable & 63)) != 0
+}
+
+func (m member) set(variable int, value bool) {
+	if value {
+		m[variable >> 6] |= 1 << (variable & 63)
+	} else {
+		m[variable >> 6] &^= 1 << (variable & 63)
 	}
-	return ranks
 }
 
+func (m member) flip(variable int) {
+	m[variable >> 6] ^= 1 << (variable & 63)
+}
+
+// crossOver takes each variable from either parent with equal probability,
+// drawing a random mask for 64 variables at a time.
 func (m member) crossOver(_member member, random *rand.Rand) member {
-	child := member{}
-	keys := lo.Keys(m)
-	sort.Strings(keys)
-	for _, k := range keys {
-		if random.Intn(2) == 1 {
-			child[k] = m[k]
-		} else {
-			child[k] = _member[k]
+	child := make(member, len(m))
+	for word := range m {
+		mask := random.Uint64()
+		child[word] = m[word] & mask | _member[word] &^ mask
+	}
+	return child
+}
+
+// splice takes the variables from index from up to index to from _member and
+// the rest from m.
+func (m member) splice(_member member, from int, to int) member {
+	child := m.clone()
+	for variable := from; variable < to; {
+		word := variable >> 6
+		high := to - word << 6
+		if high > 64 {
+			high = 64
 		}
+		mask := bitsBelow(high) &^ bitsBelow(variable & 63)
+		child[word] = child[word] &^ mask | _member[word] & mask
+		variable = word << 6 + high
 	}
 	return child
 }
 
+// bitsBelow sets the low count bits of a word.
+func bitsBelow(count int) uint64 {
+	if count >= 64 {
+		return ^uint64(0)
+	}
+	return 1 << count - 1
+}
+
+// key packs the words of the member into a string, equal members giving
+// equal keys.
+func (m member) key() string {
+	key := make([]byte, 8 * len(m))
+	for index, word := range m {
+		binary.LittleEndian.PutUint64(key[8 * index:], word)
+	}
+	return string(key)
+}
+
+func (m member) clone() member {
+	cloned := make(member, len(m))
+	copy(cloned, m)
+	return cloned
+}
+
 func (m member) matches(member member) bool {
-	return lo.EveryBy(lo.Keys(m), func(k string) bool {
-		return m[k] == member[k]
-	})
+	for word := range m {
+		if m[word] != member[word] {
+			return false
+		}
+	}
+	return true
 }
 
-func (m member) mutate(random *rand.Rand) member {
-	rate := 0.01
-	mutated := member{}
-	keys := lo.Keys(m)
-	sort.Strings(keys)
-	for _, k := range keys {
-		if random.Float64() < rate {
-			mutated[k] = !m[k]
-		} else {
-			mutated[k] = m[k]
+// mutate flips each of the first size variables with probability rate. The
+// gaps between flips are drawn from the geometric distribution, so the cost
+// follows the number of flips rather than the number of variables.
+func (m member) mutate(rate float64, size int, random *rand.Rand) member {
+	mutated := m.clone()
+	logKeep := math.Log1p(-rate)
+	if rate <= 0 || logKeep == 0 {
+		return mutated
+	}
+	if rate >= 1 {
+		for variable := 0; variable < size; variable++ {
+			mutated.flip(variable)
 		}
+		return mutated
+	}
+	for variable := geometricGap(logKeep, random); variable < size; variable += 1 + geometricGap(logKeep, random) {
+		mutated.flip(variable)
 	}
 	return mutated
 }
+
+// geometricGap draws how many variables in a row are left alone when each is
+// flipped with probability 1-exp(logKeep).
+func geometricGap(logKeep float64, random *rand.Rand) int {
+	gap := math.Log(1 - random.Float64()) / logKeep
+	if gap > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(gap)
+}
@@ -1,6 +1,7 @@
 package solvers
 
 import (
+	"context"
 	"math"
 	"math/rand"
 
@@ -15,34 +16,48 @@ func NewPopulationGenerator(randomFactory factories.RandomFactory) *PopulationGe
 	return &PopulationGenerator{randomFactory: randomFactory}
 }
 
-func (g *PopulationGenerator) generatePopulation(maxPopulation int, names []string) population {
-	population := g.generateBaseMembers(names)
-	target := int(math.Min(float64(maxPopulation), math.Pow(2, float64(len(names)))))
-	random := g.randomFactory.Build()
+// generatePopulation returns members over size variables, as many as
+// maxPopulation allows up to every possible assignment. If ctx ends first, it
+// returns just the all true and all false members along with the context's
+// error, as scoring the random members drawn so far could take longer still.
+func (g *PopulationGenerator) generatePopulation(ctx context.Context, maxPopulation int, size int) (population, error) {
+	return g.generatePopulationFrom(ctx, maxPopulation, size, g.randomFactory.Build())
+}
+
+// generatePopulationFrom draws the members from random instead of the
+// generator's random factory.
+func (g *PopulationGenerator) generatePopulationFrom(ctx context.Context, maxPopulation int, size int, random *rand.Rand) (population, error) {
+	baseMembers := g.generateBaseMembers(size)
+	population := append(population{}, baseMembers...)
+	members := newMemberSet(population)
+	target := int(math.Min(float64(maxPopulation), math.Pow(2, float64(size))))
 	for i := len(population); i < target; i++ {
-		member := g.generateMember(names, random)
-		for population.memberExists(member) {
-			member = g.generateMember(names, random)
+		if ctx.Err() != nil {
+			return baseMembers, ctx.Err()
+		}
+		member := g.generateMember(size, random)
+		for members.contains(member) {
+			member = g.generateMember(size, random)
 		}
+		members.add(member)
 		population = append(population, member)
 	}
-	return population
+	return population, nil
 }
 
-func (g *PopulationGenerator) generateMember(names []string, random *rand.Rand) member {
-	member := member{}
-	for _, name := range names {
-		member[name] = random.Intn(2) == 1
+func (g *PopulationGenerator) generateMember(size int, random *rand.Rand) member {
+	member := newMember(size)
+	for variable := 0; variable < size; variable++ {
+		member.set(variable, random.Intn(2) == 1)
 	}
 	return member
 }
 
-func (g *PopulationGenerator) generateBaseMembers(names []string) population {
-	positiveMember := member{}
-	negativeMember := member{}
-	for _, name := range names {
-		positiveMember[name] = true
-		negativeMember[name] = false
+func (g *PopulationGenerator) generateBaseMembers(size int) population {
+	positiveMember := newMember(size)
+	negativeMember := newMember(size)
+	for variable := 0; variable < size; variable++ {
+		positiveMember.set(variable, true)
 	}
 	return population{positiveMember, negativeMember}
 }
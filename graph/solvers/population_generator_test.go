@@ -1,6 +1,7 @@
 package solvers
 
 import (
+	"context"
 	"testing"
 
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
@@ -9,30 +10,30 @@ import (
 func TestGeneratePopulationLessThanMax(t *testing.T) {
 	cases := []struct {
 		desc string
-		input []string
+		input int
 		want population
 	}{
-		{ "single variable yields two members", []string{ "var" }, population{
-				{ "var": true },
-				{ "var": false },
+		{ "single variable yields two members", 1, population{
+				memberOf(true),
+				memberOf(false),
 			},
 		},
-		{ "two variables yields four members", []string{ "var1", "var2" }, population{
-				{ "var1": true, "var2": true },
-				{ "var1": false, "var2": false },
-				{ "var1": true, "var2": false },
-				{ "var1": false, "var2": true },
+		{ "two variables yields four members", 2, population{
+				memberOf(true, true),
+				memberOf(false, false),
+				memberOf(true, false),
+				memberOf(false, true),
 			},
 		},
-		{ "three variables yields eight members", []string{ "var1", "var2", "var3" }, population{
-				{ "var1": true, "var2": true, "var3": true },
-				{ "var1": true, "var2": true, "var3": false },
-				{ "var1": true, "var2": false, "var3": false },
-				{ "var1": true, "var2": false, "var3": true },
-				{ "var1": false, "var2": true, "var3": true },
-				{ "var1": false, "var2": true, "var3": false },
-				{ "var1": false, "var2": false, "var3": true },
-				{ "var1": false, "var2": false, "var3": false },
+		{ "three variables yields eight members", 3, population{
+				memberOf(true, true, true),
+				memberOf(true, true, false),
+				memberOf(true, false, false),
+				memberOf(true, false, true),
+				memberOf(false, true, true),
+				memberOf(false, true, false),
+				memberOf(false, false, true),
+				memberOf(false, false, false),
 			},
 		},
 	}
@@ -44,9 +45,12 @@ func TestGeneratePopulationLessThanMax(t *testing.T) {
 			sut := &PopulationGenerator{randomFactory: randomFactory}
 
 			// act
-			got := sut.generatePopulation(maxPopulation, tc.input)
+			got, err := sut.generatePopulation(context.Background(), maxPopulation, tc.input)
 
 			// assert
+			if err != nil {
+				t.Fatalf("returned an error: %v", err)
+			}
 			assertPopulationsAreEqual(t, got, tc.want)
 		})
 	}
@@ -55,9 +59,10 @@ func TestGeneratePopulationLessThanMax(t *testing.T) {
 func TestGeneratePopulationOverMax(t *testing.T) {
 	cases := []struct {
 		desc string
-		input []string
+		input int
 	}{
-		{ "four variables yields max pop size", []string{ "var1", "var2", "var3", "var4" } },
+		{ "four variables yields max pop size", 4 },
+		{ "members span several words", 130 },
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -67,25 +72,57 @@ func TestGeneratePopulationOverMax(t *testing.T) {
 			sut := &PopulationGenerator{randomFactory: randomFactory}
 
 			// act
-			got := sut.generatePopulation(maxPopulation, tc.input)
+			got, err := sut.generatePopulation(context.Background(), maxPopulation, tc.input)
 
 			// assert
-			assertPopulationHasCase(t, got, map[string]bool{ "var1": true, "var2": true, "var3": true, "var4": true })
-			assertPopulationHasCase(t, got, map[string]bool{ "var1": false, "var2": false, "var3": false, "var4": false })
+			if err != nil {
+				t.Fatalf("returned an error: %v", err)
+			}
+			if len(got) != maxPopulation {
+				t.Fatalf("incorrect population size: got %d want %d", len(got), maxPopulation)
+			}
+			assertPopulationHasCase(t, got, allOf(tc.input, true))
+			assertPopulationHasCase(t, got, allOf(tc.input, false))
 			assertMembersAreUnique(t, got)
 		})
 	}
 }
 
-func assertPopulationHasCase(t testing.TB, got population, member map[string]bool) {
+func TestGeneratePopulationWhenCancelled(t *testing.T) {
+	// arrange
+	sut := &PopulationGenerator{randomFactory: &factories.ZeroRandomFactory{}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// act
+	got, err := sut.generatePopulation(ctx, 100000, 200)
+
+	// assert
+	if err != context.Canceled {
+		t.Errorf("wrong error: got %v want %v", err, context.Canceled)
+	}
+	assertPopulationsAreEqual(t, got, population{ allOf(200, true), allOf(200, false) })
+}
+
+func memberOf(values ...bool) member {
+	m := newMember(len(values))
+	for variable, value := range values {
+		m.set(variable, value)
+	}
+	return m
+}
+
+func allOf(size int, value bool) member {
+	values := make([]bool, size)
+	for variable := range values {
+		values[variable] = value
+	}
+	return memberOf(values...)
+}
+
+func assertPopulationHasCase(t testing.TB, got population, member member) {
 	for _, m := range got {
-		found := true
-		for k, v := range m {
-			if v != member[k] {
-				found = false
-			}
-		}
-		if found {
+		if m.matches(member) {
 			return
 		}
 	}
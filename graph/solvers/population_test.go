@@ -0,0 +1,175 @@
+package solvers
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+)
+
+func TestClauseTableScore(t *testing.T) {
+	cases := []struct {
+		desc string
+		variables int
+		mixedWidth bool
+	}{
+		{ "three literals per clause", 100, false },
+		{ "mixed widths with tautologies", 8, true },
+		{ "several words per member", 200, true },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			random := rand.New(rand.NewSource(1))
+			job := randomJob(random, tc.variables, tc.variables * 4)
+			if tc.mixedWidth {
+				job = randomMixedWidthJob(random, tc.variables, tc.variables * 4)
+			}
+			sut := newClauseTable(job)
+			generator := &PopulationGenerator{randomFactory: &factories.SeededRandomFactory{Seed: 1}}
+			population, _ := generator.generatePopulation(context.Background(), 20, sut.size())
+
+			for _, member := range population {
+				// act
+				got := sut.score(member)
+
+				// assert
+				want := job.Score(sut.assignment(member))
+				if got != want {
+					t.Errorf("wrong score for %v: got %f want %f", member, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMemberCrossOver(t *testing.T) {
+	// arrange
+	random := rand.New(rand.NewSource(1))
+	size := 130
+	parent1 := (&PopulationGenerator{}).generateMember(size, random)
+	parent2 := (&PopulationGenerator{}).generateMember(size, random)
+
+	// act
+	got := parent1.crossOver(parent2, random)
+
+	// assert
+	fromParent1, fromParent2 := 0, 0
+	for variable := 0; variable < size; variable++ {
+		if got.get(variable) != parent1.get(variable) && got.get(variable) != parent2.get(variable) {
+			t.Fatalf("variable %d comes from neither parent", variable)
+		}
+		if parent1.get(variable) != parent2.get(variable) {
+			if got.get(variable) == parent1.get(variable) {
+				fromParent1++
+			} else {
+				fromParent2++
+			}
+		}
+	}
+	if fromParent1 == 0 || fromParent2 == 0 {
+		t.Errorf("failed to mix parents: got %d and %d differing variables from each", fromParent1, fromParent2)
+	}
+	if got[len(got) - 1] >> (size % 64) != 0 {
+		t.Errorf("set bits past the last variable: %x", got[len(got) - 1])
+	}
+}
+
+func TestMemberMutate(t *testing.T) {
+	cases := []struct {
+		desc string
+		rate float64
+		size int
+		minFlips int
+		maxFlips int
+	}{
+		{ "zero rate keeps the member", 0.0, 130, 0, 0 },
+		{ "full rate flips every variable", 1.0, 130, 130, 130 },
+		{ "flips about rate of the variables", 0.1, 10000, 850, 1150 },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			random := rand.New(rand.NewSource(1))
+			original := (&PopulationGenerator{}).generateMember(tc.size, random)
+
+			// act
+			got := original.mutate(tc.rate, tc.size, random)
+
+			// assert
+			flips := 0
+			for variable := 0; variable < tc.size; variable++ {
+				if got.get(variable) != original.get(variable) {
+					flips++
+				}
+			}
+			if flips < tc.minFlips || flips > tc.maxFlips {
+				t.Errorf("wrong number of flips: got %d want between %d and %d", flips, tc.minFlips, tc.maxFlips)
+			}
+			if got[len(got) - 1] >> (tc.size % 64) != 0 {
+				t.Errorf("set bits past the last variable: %x", got[len(got) - 1])
+			}
+		})
+	}
+}
+
+// BenchmarkScore compares Job.Score on a map assignment, which the genetic
+// solver used before members were bitsets, with scoring a member against the
+// compiled clause table.
+func BenchmarkScore(b *testing.B) {
+	for _, variables := range []int{100, 300} {
+		random := rand.New(rand.NewSource(1))
+		job := randomJob(random, variables, variables * 4)
+		table := newClauseTable(job)
+		member := (&PopulationGenerator{}).generateMember(table.size(), random)
+		assignment := table.assignment(member)
+		b.Run(fmt.Sprintf("map/%d", variables), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				job.Score(assignment)
+			}
+		})
+		b.Run(fmt.Sprintf("bitset/%d", variables), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				table.score(member)
+			}
+		})
+	}
+}
+
+func BenchmarkBreed(b *testing.B) {
+	for _, variables := range []int{100, 300} {
+		b.Run(fmt.Sprint(variables), func(b *testing.B) {
+			random := rand.New(rand.NewSource(1))
+			parent1 := (&PopulationGenerator{}).generateMember(variables, random)
+			parent2 := (&PopulationGenerator{}).generateMember(variables, random)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				parent1.crossOver(parent2, random).mutate(defaultMutationRate, variables, random)
+			}
+		})
+	}
+}
+
+// BenchmarkGeneration times one generation of a population of 100. With map
+// members this took about 27ms for 100 variables and 84ms for 300 variables,
+// on a machine where the bitset members take about 1.4ms and 4.2ms.
+func BenchmarkGeneration(b *testing.B) {
+	for _, variables := range []int{100, 300} {
+		b.Run(fmt.Sprint(variables), func(b *testing.B) {
+			random := rand.New(rand.NewSource(1))
+			table := newClauseTable(randomJob(random, variables, variables * 4))
+			generator := &PopulationGenerator{randomFactory: &factories.SeededRandomFactory{Seed: 1}}
+			population, _ := generator.generatePopulation(context.Background(), 100, table.size())
+			sut := &geneticSolver{maxPopulation: 100, mutationRate: defaultMutationRate}
+			operators := sut.operators.build(sut.mutationRate, table)
+			bestMember, _ := population.best(table)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				population = sut.reproduce(context.Background(), table, operators, population, bestMember, random)
+				bestMember, _ = population.best(table)
+			}
+		})
+	}
+}
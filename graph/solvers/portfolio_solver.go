@@ -0,0 +1,162 @@
+package solvers
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+// PortfolioMember is one of the solvers raced by a portfolio, along with the
+// type it is recorded as when it wins. Members run concurrently, so they
+// must not share a random source.
+type PortfolioMember struct {
+	SolverType model.SolverType
+	Solver Solver
+}
+
+type portfolioSolver struct {
+	members []PortfolioMember
+	maxTime time.Duration
+	solutionFactory *factories.SolutionFactory
+}
+
+func NewPortfolioSolver(
+	members []PortfolioMember,
+	maxTime time.Duration,
+	solutionFactory *factories.SolutionFactory,
+) *portfolioSolver {
+	return &portfolioSolver{
+		members: members,
+		maxTime: maxTime,
+		solutionFactory: solutionFactory,
+	}
+}
+
+type portfolioResult struct {
+	index int
+	solution *model.Solution
+}
+
+// Solve runs every member on the job at once. The first member to settle the
+// job, whether satisfiable or unsatisfiable, wins and the others are
+// cancelled. If none does, the best scoring solution wins once every member
+// has stopped, the earlier member in the portfolio taking ties. Either way
+// the winning member is recorded in the solution's SolvedBy. A member that
+// panics ends with an ERROR solution, which only wins if every member fails.
+func (s *portfolioSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, s.maxTime)
+	defer cancel()
+	reporter := &portfolioReporter{
+		reporter: progressReporter(ctx),
+		cycles: make([]int, len(s.members)),
+	}
+	results := make(chan portfolioResult, len(s.members))
+	for index, member := range s.members {
+		go func(index int, member PortfolioMember) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("portfolio member %s failed on job %s: %v", member.SolverType, job.Uuid.String(), r)
+					results <- portfolioResult{index: index, solution: s.solutionFactory.ConstructEmptySolution(job, time.Since(start), model.SolutionStatusError)}
+				}
+			}()
+			memberCtx := WithProgressReporter(ctx, &portfolioMemberReporter{portfolio: reporter, index: index})
+			results <- portfolioResult{index: index, solution: member.Solver.Solve(memberCtx, job)}
+		}(index, member)
+	}
+	solutions := make([]*model.Solution, len(s.members))
+	winner := -1
+	for range s.members {
+		result := <-results
+		solutions[result.index] = result.solution
+		if winner < 0 && settled(result.solution.Status) {
+			winner = result.index
+			cancel()
+		}
+	}
+	if winner < 0 {
+		winner = bestSolution(solutions)
+	}
+	solution := solutions[winner]
+	solvedBy := s.members[winner].SolverType
+	solution.SolvedBy = &solvedBy
+	return solution
+}
+
+func settled(status model.SolutionStatus) bool {
+	return status == model.SolutionStatusSatisfiable || status == model.SolutionStatusUnsatisfiable
+}
+
+// bestSolution returns the index of the first solution holding the best
+// score, passing over ERROR solutions unless there is nothing else.
+func bestSolution(solutions []*model.Solution) int {
+	best := -1
+	for index, solution := range solutions {
+		if solution.Status == model.SolutionStatusError {
+			continue
+		}
+		if best < 0 || solution.Score > solutions[best].Score {
+			best = index
+		}
+	}
+	if best < 0 {
+		return 0
+	}
+	return best
+}
+
+// portfolioReporter merges the progress of the members into one report
+// stream. Its cycles are the sum of the latest cycles of every member and its
+// best score is the best any member has reported, so neither goes backwards.
+// A report that doesn't add any cycles is dropped, but an incumbent it
+// improved is passed on with the next one.
+type portfolioReporter struct {
+	mutex sync.Mutex
+	reporter ProgressReporter
+	cycles []int
+	reported bool
+	reportedCycles int
+	bestScore *float64
+	best map[string]bool
+	improved bool
+}
+
+func (r *portfolioReporter) report(index int, progress Progress) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.cycles[index] = progress.Cycles
+	if progress.BestScore != nil && (r.bestScore == nil || *progress.BestScore > *r.bestScore) {
+		bestScore := *progress.BestScore
+		r.bestScore = &bestScore
+		r.best = progress.Best
+		r.improved = true
+	}
+	cycles := 0
+	for _, memberCycles := range r.cycles {
+		cycles += memberCycles
+	}
+	if r.reported && cycles <= r.reportedCycles {
+		return
+	}
+	var best map[string]bool
+	if r.improved {
+		best = r.best
+	}
+	r.reporter.Report(Progress{Cycles: cycles, BestScore: r.bestScore, Best: best})
+	r.reported = true
+	r.reportedCycles = cycles
+	r.improved = false
+}
+
+type portfolioMemberReporter struct {
+	portfolio *portfolioReporter
+	index int
+}
+
+func (r *portfolioMemberReporter) Report(progress Progress) {
+	r.portfolio.report(r.index, progress)
+}
@@ -0,0 +1,166 @@
+package solvers
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+// fixedSolver returns a solution with the given status and score, or, when
+// blocking, waits until it is cancelled and returns it marked CANCELLED.
+type fixedSolver struct {
+	status model.SolutionStatus
+	score float64
+	blocking bool
+}
+
+func (s *fixedSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
+	status := s.status
+	if s.blocking {
+		<-ctx.Done()
+		status = model.SolutionStatusCancelled
+	}
+	return &model.Solution{
+		Uuid: job.Uuid,
+		Variables: []*model.SolvedVariable{},
+		Score: s.score,
+		Status: status,
+	}
+}
+
+type panickingSolver struct {
+}
+
+func (s *panickingSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
+	panic("solver failure")
+}
+
+func TestPortfolioSolve(t *testing.T) {
+	cases := []struct {
+		desc string
+		members []PortfolioMember
+		wantStatus model.SolutionStatus
+		wantScore float64
+		wantSolvedBy model.SolverType
+	}{
+		{ "first settled answer wins over better scores", []PortfolioMember{
+				{ SolverType: model.SolverTypeGenetic, Solver: &fixedSolver{score: 0.9, blocking: true} },
+				{ SolverType: model.SolverTypeCdcl, Solver: &fixedSolver{status: model.SolutionStatusUnsatisfiable, score: 0.5} },
+			}, model.SolutionStatusUnsatisfiable, 0.5, model.SolverTypeCdcl,
+		},
+		{ "best score wins without a settled answer", []PortfolioMember{
+				{ SolverType: model.SolverTypeWalksat, Solver: &fixedSolver{status: model.SolutionStatusUnknown, score: 0.8} },
+				{ SolverType: model.SolverTypeGsat, Solver: &fixedSolver{status: model.SolutionStatusUnknown, score: 0.9} },
+				{ SolverType: model.SolverTypeTabu, Solver: &fixedSolver{status: model.SolutionStatusUnknown, score: 0.9} },
+			}, model.SolutionStatusUnknown, 0.9, model.SolverTypeGsat,
+		},
+		{ "failing member loses to the others", []PortfolioMember{
+				{ SolverType: model.SolverTypeCdcl, Solver: &panickingSolver{} },
+				{ SolverType: model.SolverTypeWalksat, Solver: &fixedSolver{status: model.SolutionStatusUnknown, score: 0.0} },
+			}, model.SolutionStatusUnknown, 0.0, model.SolverTypeWalksat,
+		},
+		{ "every member failing gives an error", []PortfolioMember{
+				{ SolverType: model.SolverTypeCdcl, Solver: &panickingSolver{} },
+				{ SolverType: model.SolverTypeDpll, Solver: &panickingSolver{} },
+			}, model.SolutionStatusError, 0.0, model.SolverTypeCdcl,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			maxTime, _ := time.ParseDuration("10s")
+			sut := NewPortfolioSolver(tc.members, maxTime, &factories.SolutionFactory{})
+
+			// act
+			start := time.Now()
+			got := sut.Solve(context.Background(), singleClauseJob())
+
+			// assert
+			if time.Since(start) > time.Second {
+				t.Errorf("failed to cancel the other members: took %v", time.Since(start))
+			}
+			assertStatusesAreEqual(t, got, tc.wantStatus)
+			if got.Score != tc.wantScore {
+				t.Errorf("wrong score: got %f want %f", got.Score, tc.wantScore)
+			}
+			if got.SolvedBy == nil || *got.SolvedBy != tc.wantSolvedBy {
+				t.Errorf("wrong member: got %v want %s", got.SolvedBy, tc.wantSolvedBy)
+			}
+		})
+	}
+}
+
+func TestPortfolioRacesRealSolvers(t *testing.T) {
+	maxTime, _ := time.ParseDuration("10s")
+	factory := &factories.SolutionFactory{}
+	randomFactory := &factories.SeededRandomFactory{Seed: 1}
+	cases := []struct {
+		desc string
+		job *model.Job
+		want model.SolutionStatus
+		wantSolvedBy model.SolverType
+	}{
+		{ "complete solver proves a job unsatisfiable", everyCombinationJob(), model.SolutionStatusUnsatisfiable, model.SolverTypeCdcl },
+		{ "planted job is satisfied", plantedJob(rand.New(rand.NewSource(0)), 200, 800), model.SolutionStatusSatisfiable, "" },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := NewPortfolioSolver([]PortfolioMember{
+				{ SolverType: model.SolverTypeWalksat, Solver: NewWalkSatSolver(10, 100000, 0.5, maxTime, factory, randomFactory) },
+				{ SolverType: model.SolverTypeCdcl, Solver: NewCdclSolver(maxTime, factory) },
+			}, maxTime, factory)
+
+			// act
+			got := sut.Solve(context.Background(), tc.job)
+
+			// assert
+			assertStatusesAreEqual(t, got, tc.want)
+			if got.SolvedBy == nil {
+				t.Fatalf("failed to record the winning member")
+			}
+			if tc.wantSolvedBy != "" && *got.SolvedBy != tc.wantSolvedBy {
+				t.Errorf("wrong member: got %s want %s", *got.SolvedBy, tc.wantSolvedBy)
+			}
+		})
+	}
+}
+
+func TestPortfolioReporter(t *testing.T) {
+	// arrange
+	recorder := &recordingReporter{}
+	sut := &portfolioReporter{reporter: recorder, cycles: make([]int, 2)}
+	first := map[string]bool{ "v1": true }
+	second := map[string]bool{ "v1": false }
+
+	// act
+	sut.report(0, scoredProgress(0, 0.5, first))
+	sut.report(1, scoredProgress(0, 0.75, second))
+	sut.report(1, scoredProgress(10, 0.75, nil))
+	sut.report(0, scoredProgress(5, 0.5, nil))
+
+	// assert
+	want := []struct {
+		cycles int
+		bestScore float64
+		best map[string]bool
+	}{
+		{ 0, 0.5, first },
+		{ 10, 0.75, second },
+		{ 15, 0.75, nil },
+	}
+	if len(recorder.reports) != len(want) {
+		t.Fatalf("wrong number of reports: got %d want %d", len(recorder.reports), len(want))
+	}
+	for index, report := range recorder.reports {
+		if report.Cycles != want[index].cycles || *report.BestScore != want[index].bestScore || (report.Best == nil) != (want[index].best == nil) ||
+			(report.Best != nil && report.Best["v1"] != want[index].best["v1"]) {
+			t.Errorf("report %d got (%d %f %v) want (%d %f %v)", index, report.Cycles, *report.BestScore, report.Best,
+				want[index].cycles, want[index].bestScore, want[index].best)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package solvers
+
+import (
+	"context"
+)
+
+// Progress is a snapshot of a running solver. BestScore is nil for solvers
+// that hold no complete assignment until they finish. Best is the incumbent,
+// the best assignment found so far, when it has improved since the previous
+// report and nil otherwise; receivers must not modify it. Temperature is set
+// by the simulated annealing solver only.
+type Progress struct {
+	Cycles int
+	BestScore *float64
+	Best map[string]bool
+	Temperature *float64
+}
+
+// ProgressReporter receives the progress of a running solver. Solvers report
+// from their main loop, so implementations must return quickly.
+type ProgressReporter interface {
+	Report(progress Progress)
+}
+
+// progressInterval is how many cycles solvers with cheap cycles, such as
+// decisions, conflicts or flips, run between reports.
+const progressInterval = 1024
+
+type progressReporterKey struct{}
+
+type nopProgressReporter struct{}
+
+func (nopProgressReporter) Report(progress Progress) {}
+
+// WithProgressReporter returns a context that makes solvers report their
+// progress to reporter.
+func WithProgressReporter(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressReporterKey{}, reporter)
+}
+
+func progressReporter(ctx context.Context) ProgressReporter {
+	if reporter, ok := ctx.Value(progressReporterKey{}).(ProgressReporter); ok {
+		return reporter
+	}
+	return nopProgressReporter{}
+}
+
+func scoredProgress(cycles int, bestScore float64, best map[string]bool) Progress {
+	return Progress{Cycles: cycles, BestScore: &bestScore, Best: best}
+}
@@ -0,0 +1,52 @@
+package solvers
+
+import (
+	"math/rand"
+)
+
+// refinement turns the genetic solver into a memetic algorithm: with
+// probability probability it improves a child with up to flips WalkSAT flips,
+// keeping the assignment with the fewest unsatisfied clauses seen along the
+// way. Each run gets its own, since the local search state is reused from one
+// child to the next.
+type refinement struct {
+	search *localSearch
+	flips int
+	probability float64
+	noise float64
+	best []bool
+}
+
+func newRefinement(table *clauseTable, flips int, probability float64, noise float64) *refinement {
+	return &refinement{
+		search: newLocalSearch(table.formula),
+		flips: flips,
+		probability: probability,
+		noise: noise,
+		best: make([]bool, table.size()),
+	}
+}
+
+func (r *refinement) refine(child member, random *rand.Rand) member {
+	if random.Float64() >= r.probability {
+		return child
+	}
+	for variable := range r.search.values {
+		r.search.values[variable] = child.get(variable)
+	}
+	r.search.reset()
+	copy(r.best, r.search.values)
+	bestUnsatisfied := len(r.search.unsatisfied)
+	for flip := 0; flip < r.flips && !r.search.solved(); flip++ {
+		r.search.flip(walkSatPick(r.search, random, r.noise))
+		if len(r.search.unsatisfied) < bestUnsatisfied {
+			copy(r.best, r.search.values)
+			bestUnsatisfied = len(r.search.unsatisfied)
+		}
+	}
+	refined := newMember(len(r.best))
+	for variable, value := range r.best {
+		refined.set(variable, value)
+	}
+	return refined
+}
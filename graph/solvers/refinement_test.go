@@ -0,0 +1,61 @@
+package solvers
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestRefine(t *testing.T) {
+	cases := []struct {
+		desc string
+		flips int
+		probability float64
+		changed bool
+	}{
+		{ "refines with full probability", 50, 1.0, true },
+		{ "leaves children alone with zero probability", 50, 0.0, false },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			random := rand.New(rand.NewSource(1))
+			table := newClauseTable(plantedJob(random, 100, 400))
+			sut := newRefinement(table, tc.flips, tc.probability, defaultNoise)
+
+			for i := 0; i < 20; i++ {
+				child := (&PopulationGenerator{}).generateMember(table.size(), random)
+
+				// act
+				got := sut.refine(child, random)
+
+				// assert
+				if tc.changed && table.satisfied(got) <= table.satisfied(child) {
+					t.Errorf("failed to improve the child: got %d satisfied clauses from %d", table.satisfied(got), table.satisfied(child))
+				}
+				if !tc.changed && !got.matches(child) {
+					t.Errorf("changed the child")
+				}
+			}
+		})
+	}
+}
+
+func TestMemeticSolve(t *testing.T) {
+	// arrange
+	maxTime, _ := time.ParseDuration("10s")
+	randomFactory := &factories.SeededRandomFactory{Seed: 1}
+	operators := GeneticOperators{ RefinementFlips: 100, RefinementProbability: 0.5, Noise: defaultNoise }
+	sut := NewGeneticSolver(20, maxTime, 0, 0.01, operators, &factories.SolutionFactory{}, NewPopulationGenerator(randomFactory), randomFactory)
+	job := plantedJob(rand.New(rand.NewSource(0)), 200, 800)
+
+	// act
+	got := sut.Solve(context.Background(), job)
+
+	// assert
+	assertStatusesAreEqual(t, got, model.SolutionStatusSatisfiable)
+}
@@ -0,0 +1,330 @@
+package solvers
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+const (
+	defaultPopulationSize = 10
+	defaultTimeLimit = 10 * time.Second
+	defaultMutationRate = 0.01
+	defaultNoise = 0.5
+	defaultMaxFlips = 100000
+	defaultMaxTries = 10
+	defaultSelection = model.SelectionOperatorRoulette
+	defaultCrossover = model.CrossoverOperatorUniform
+	defaultMutation = model.MutationOperatorFixed
+	defaultTournamentSize = 3
+	defaultIslands = 4
+	defaultMigrationInterval = 50
+	defaultMigrants = 2
+	defaultRefinementProbability = 1.0
+	defaultCoolingSchedule = model.CoolingScheduleGeometric
+	defaultInitialTemperature = 2.0
+	defaultFinalTemperature = 0.05
+	defaultTabuTenure = 10
+	defaultAspiration = model.AspirationCriterionBest
+)
+
+// The limits keep a single job from holding a worker with a population too
+// big to generate or breed in any reasonable time.
+const (
+	populationSizeLimit = 10000
+	islandsLimit = 64
+	threadsLimit = 256
+)
+
+var defaultPortfolio = []model.SolverType{model.SolverTypeCdcl, model.SolverTypeWalksat, model.SolverTypeGenetic}
+
+type SolverBuilder func(parameters *model.SolverParameters) Solver
+
+type Registry struct {
+	builders map[model.SolverType]SolverBuilder
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		builders: map[model.SolverType]SolverBuilder{},
+	}
+}
+
+// NewDefaultRegistry registers every solver in this package, filling in any
+// parameter a job leaves unset with the package defaults.
+func NewDefaultRegistry(
+	solutionFactory *factories.SolutionFactory,
+	randomFactory factories.RandomFactory,
+) *Registry {
+	r := NewRegistry()
+	r.Register(model.SolverTypeNaive, func(parameters *model.SolverParameters) Solver {
+		return NewNaiveSolver(solutionFactory)
+	})
+	r.Register(model.SolverTypeGenetic, func(parameters *model.SolverParameters) Solver {
+		return newGeneticSolverFromParameters(parameters, solutionFactory, randomFactory)
+	})
+	r.Register(model.SolverTypeIsland, func(parameters *model.SolverParameters) Solver {
+		return NewIslandSolver(
+			newGeneticSolverFromParameters(parameters, solutionFactory, randomFactory),
+			intParameter(parameters.Islands, defaultIslands),
+			intParameter(parameters.MigrationInterval, defaultMigrationInterval),
+			intParameter(parameters.Migrants, defaultMigrants),
+			intParameter(parameters.Threads, runtime.NumCPU()),
+		)
+	})
+	r.Register(model.SolverTypeDpll, func(parameters *model.SolverParameters) Solver {
+		return NewDpllSolver(timeLimitParameter(parameters), solutionFactory)
+	})
+	r.Register(model.SolverTypeCdcl, func(parameters *model.SolverParameters) Solver {
+		return NewCdclSolver(timeLimitParameter(parameters), solutionFactory)
+	})
+	r.Register(model.SolverTypeWalksat, func(parameters *model.SolverParameters) Solver {
+		return NewWalkSatSolver(
+			intParameter(parameters.MaxTries, defaultMaxTries),
+			intParameter(parameters.MaxFlips, defaultMaxFlips),
+			floatParameter(parameters.Noise, defaultNoise),
+			timeLimitParameter(parameters),
+			solutionFactory,
+			seededRandomFactory(parameters, randomFactory),
+		)
+	})
+	r.Register(model.SolverTypeGsat, func(parameters *model.SolverParameters) Solver {
+		return NewGsatSolver(
+			intParameter(parameters.MaxTries, defaultMaxTries),
+			intParameter(parameters.MaxFlips, defaultMaxFlips),
+			floatParameter(parameters.Noise, defaultNoise),
+			timeLimitParameter(parameters),
+			solutionFactory,
+			seededRandomFactory(parameters, randomFactory),
+		)
+	})
+	r.Register(model.SolverTypeSimulatedAnnealing, func(parameters *model.SolverParameters) Solver {
+		schedule := defaultCoolingSchedule
+		if parameters.CoolingSchedule != nil {
+			schedule = *parameters.CoolingSchedule
+		}
+		return NewSimulatedAnnealingSolver(
+			intParameter(parameters.MaxTries, defaultMaxTries),
+			intParameter(parameters.MaxFlips, defaultMaxFlips),
+			floatParameter(parameters.InitialTemperature, defaultInitialTemperature),
+			floatParameter(parameters.FinalTemperature, defaultFinalTemperature),
+			schedule,
+			timeLimitParameter(parameters),
+			solutionFactory,
+			seededRandomFactory(parameters, randomFactory),
+		)
+	})
+	r.Register(model.SolverTypeTabu, func(parameters *model.SolverParameters) Solver {
+		aspiration := defaultAspiration
+		if parameters.Aspiration != nil {
+			aspiration = *parameters.Aspiration
+		}
+		return NewTabuSolver(
+			intParameter(parameters.MaxTries, defaultMaxTries),
+			intParameter(parameters.MaxFlips, defaultMaxFlips),
+			intParameter(parameters.TabuTenure, defaultTabuTenure),
+			aspiration,
+			timeLimitParameter(parameters),
+			solutionFactory,
+			seededRandomFactory(parameters, randomFactory),
+		)
+	})
+	r.Register(model.SolverTypePortfolio, func(parameters *model.SolverParameters) Solver {
+		solverTypes := parameters.Portfolio
+		if solverTypes == nil {
+			solverTypes = defaultPortfolio
+		}
+		members := []PortfolioMember{}
+		for _, solverType := range solverTypes {
+			members = append(members, PortfolioMember{SolverType: solverType, Solver: r.builders[solverType](parameters)})
+		}
+		return NewPortfolioSolver(members, timeLimitParameter(parameters), solutionFactory)
+	})
+	return r
+}
+
+func newGeneticSolverFromParameters(
+	parameters *model.SolverParameters,
+	solutionFactory *factories.SolutionFactory,
+	randomFactory factories.RandomFactory,
+) *geneticSolver {
+	random := seededRandomFactory(parameters, randomFactory)
+	return NewGeneticSolver(
+		intParameter(parameters.PopulationSize, defaultPopulationSize),
+		timeLimitParameter(parameters),
+		intParameter(parameters.MaxGenerations, 0),
+		floatParameter(parameters.MutationRate, defaultMutationRate),
+		geneticOperatorsParameter(parameters),
+		solutionFactory,
+		NewPopulationGenerator(random),
+		random,
+	)
+}
+
+func (r *Registry) Register(solverType model.SolverType, builder SolverBuilder) {
+	r.builders[solverType] = builder
+}
+
+func (r *Registry) Build(solverType model.SolverType, parameters *model.SolverParameters) (Solver, error) {
+	builder, found := r.builders[solverType]
+	if !found {
+		return nil, fmt.Errorf("no solver registered for %s", solverType)
+	}
+	if parameters == nil {
+		parameters = &model.SolverParameters{}
+	}
+	err := validateParameters(parameters)
+	if err == nil {
+		err = r.validatePortfolio(parameters)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return builder(parameters), nil
+}
+
+// validatePortfolio checks that every portfolio member can be built, and
+// that no portfolio races another one.
+func (r *Registry) validatePortfolio(parameters *model.SolverParameters) error {
+	if parameters.Portfolio == nil {
+		return nil
+	}
+	if len(parameters.Portfolio) == 0 {
+		return fmt.Errorf("portfolio must not be empty")
+	}
+	for _, solverType := range parameters.Portfolio {
+		if solverType == model.SolverTypePortfolio {
+			return fmt.Errorf("portfolio must not contain %s", solverType)
+		}
+		if _, found := r.builders[solverType]; !found {
+			return fmt.Errorf("no solver registered for portfolio member %s", solverType)
+		}
+	}
+	return nil
+}
+
+func validateParameters(parameters *model.SolverParameters) error {
+	if parameters.PopulationSize != nil && *parameters.PopulationSize < 2 {
+		return fmt.Errorf("populationSize must be at least 2, got %d", *parameters.PopulationSize)
+	}
+	if parameters.PopulationSize != nil && *parameters.PopulationSize > populationSizeLimit {
+		return fmt.Errorf("populationSize must be at most %d, got %d", populationSizeLimit, *parameters.PopulationSize)
+	}
+	if parameters.TimeLimit != nil && *parameters.TimeLimit <= 0 {
+		return fmt.Errorf("timeLimit must be positive, got %d", *parameters.TimeLimit)
+	}
+	if parameters.MutationRate != nil && (*parameters.MutationRate < 0 || *parameters.MutationRate > 1) {
+		return fmt.Errorf("mutationRate must be between 0 and 1, got %f", *parameters.MutationRate)
+	}
+	if parameters.Noise != nil && (*parameters.Noise < 0 || *parameters.Noise > 1) {
+		return fmt.Errorf("noise must be between 0 and 1, got %f", *parameters.Noise)
+	}
+	if parameters.MaxFlips != nil && *parameters.MaxFlips < 0 {
+		return fmt.Errorf("maxFlips must not be negative, got %d", *parameters.MaxFlips)
+	}
+	if parameters.MaxTries != nil && *parameters.MaxTries < 1 {
+		return fmt.Errorf("maxTries must be at least 1, got %d", *parameters.MaxTries)
+	}
+	if parameters.TournamentSize != nil && *parameters.TournamentSize < 2 {
+		return fmt.Errorf("tournamentSize must be at least 2, got %d", *parameters.TournamentSize)
+	}
+	if parameters.Selection != nil && *parameters.Selection == model.SelectionOperatorTournament {
+		tournamentSize := intParameter(parameters.TournamentSize, defaultTournamentSize)
+		populationSize := intParameter(parameters.PopulationSize, defaultPopulationSize)
+		if tournamentSize > populationSize {
+			return fmt.Errorf("tournamentSize must be at most populationSize %d, got %d", populationSize, tournamentSize)
+		}
+	}
+	if parameters.MaxGenerations != nil && *parameters.MaxGenerations < 1 {
+		return fmt.Errorf("maxGenerations must be at least 1, got %d", *parameters.MaxGenerations)
+	}
+	if parameters.Islands != nil && *parameters.Islands < 1 {
+		return fmt.Errorf("islands must be at least 1, got %d", *parameters.Islands)
+	}
+	if parameters.Islands != nil && *parameters.Islands > islandsLimit {
+		return fmt.Errorf("islands must be at most %d, got %d", islandsLimit, *parameters.Islands)
+	}
+	if parameters.MigrationInterval != nil && *parameters.MigrationInterval < 1 {
+		return fmt.Errorf("migrationInterval must be at least 1, got %d", *parameters.MigrationInterval)
+	}
+	if parameters.Migrants != nil && *parameters.Migrants < 0 {
+		return fmt.Errorf("migrants must not be negative, got %d", *parameters.Migrants)
+	}
+	if parameters.Threads != nil && *parameters.Threads < 1 {
+		return fmt.Errorf("threads must be at least 1, got %d", *parameters.Threads)
+	}
+	if parameters.Threads != nil && *parameters.Threads > threadsLimit {
+		return fmt.Errorf("threads must be at most %d, got %d", threadsLimit, *parameters.Threads)
+	}
+	if parameters.RefinementFlips != nil && *parameters.RefinementFlips < 0 {
+		return fmt.Errorf("refinementFlips must not be negative, got %d", *parameters.RefinementFlips)
+	}
+	if parameters.RefinementProbability != nil && (*parameters.RefinementProbability < 0 || *parameters.RefinementProbability > 1) {
+		return fmt.Errorf("refinementProbability must be between 0 and 1, got %f", *parameters.RefinementProbability)
+	}
+	if parameters.TabuTenure != nil && *parameters.TabuTenure < 0 {
+		return fmt.Errorf("tabuTenure must not be negative, got %d", *parameters.TabuTenure)
+	}
+	initialTemperature := floatParameter(parameters.InitialTemperature, defaultInitialTemperature)
+	if initialTemperature <= 0 {
+		return fmt.Errorf("initialTemperature must be positive, got %f", initialTemperature)
+	}
+	finalTemperature := floatParameter(parameters.FinalTemperature, defaultFinalTemperature)
+	if finalTemperature <= 0 || finalTemperature > initialTemperature {
+		return fmt.Errorf("finalTemperature must be positive and at most initialTemperature, got %f", finalTemperature)
+	}
+	return nil
+}
+
+func seededRandomFactory(parameters *model.SolverParameters, randomFactory factories.RandomFactory) factories.RandomFactory {
+	if parameters.Seed == nil {
+		return randomFactory
+	}
+	return &factories.SeededRandomFactory{Seed: int64(*parameters.Seed)}
+}
+
+func timeLimitParameter(parameters *model.SolverParameters) time.Duration {
+	if parameters.TimeLimit == nil {
+		return defaultTimeLimit
+	}
+	return time.Duration(*parameters.TimeLimit) * time.Millisecond
+}
+
+func geneticOperatorsParameter(parameters *model.SolverParameters) GeneticOperators {
+	operators := GeneticOperators{
+		Selection: defaultSelection,
+		Crossover: defaultCrossover,
+		Mutation: defaultMutation,
+		TournamentSize: intParameter(parameters.TournamentSize, defaultTournamentSize),
+		RefinementFlips: intParameter(parameters.RefinementFlips, 0),
+		RefinementProbability: floatParameter(parameters.RefinementProbability, defaultRefinementProbability),
+		Noise: floatParameter(parameters.Noise, defaultNoise),
+	}
+	if parameters.Selection != nil {
+		operators.Selection = *parameters.Selection
+	}
+	if parameters.Crossover != nil {
+		operators.Crossover = *parameters.Crossover
+	}
+	if parameters.Mutation != nil {
+		operators.Mutation = *parameters.Mutation
+	}
+	return operators
+}
+
+func intParameter(value *int, fallback int) int {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
+
+func floatParameter(value *float64, fallback float64) float64 {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
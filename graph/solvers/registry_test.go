@@ -0,0 +1,312 @@
+package solvers
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestRegistryBuild(t *testing.T) {
+	populationSize := 25
+	timeLimit := 500
+	mutationRate := 0.05
+	seed := 7
+	selection := model.SelectionOperatorTournament
+	crossover := model.CrossoverOperatorTwoPoint
+	mutation := model.MutationOperatorFocused
+	tournamentSize := 5
+	refinementFlips := 40
+	refinementProbability := 0.25
+	noise := 0.3
+	cases := []struct {
+		desc string
+		parameters *model.SolverParameters
+		want *geneticSolver
+	}{
+		{ "defaults are used without parameters", nil, &geneticSolver{
+			maxPopulation: defaultPopulationSize,
+			maxTime: defaultTimeLimit,
+			mutationRate: defaultMutationRate,
+			operators: GeneticOperators{ Selection: defaultSelection, Crossover: defaultCrossover, Mutation: defaultMutation, TournamentSize: defaultTournamentSize,
+				RefinementProbability: defaultRefinementProbability, Noise: defaultNoise },
+		} },
+		{ "parameters override defaults", &model.SolverParameters{
+			PopulationSize: &populationSize,
+			TimeLimit: &timeLimit,
+			MutationRate: &mutationRate,
+			Seed: &seed,
+			Selection: &selection,
+			Crossover: &crossover,
+			Mutation: &mutation,
+			TournamentSize: &tournamentSize,
+			RefinementFlips: &refinementFlips,
+			RefinementProbability: &refinementProbability,
+			Noise: &noise,
+		}, &geneticSolver{
+			maxPopulation: populationSize,
+			maxTime: 500 * time.Millisecond,
+			mutationRate: mutationRate,
+			operators: GeneticOperators{ Selection: selection, Crossover: crossover, Mutation: mutation, TournamentSize: tournamentSize,
+				RefinementFlips: refinementFlips, RefinementProbability: refinementProbability, Noise: noise },
+		} },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := NewDefaultRegistry(&factories.SolutionFactory{}, &factories.ZeroRandomFactory{})
+
+			// act
+			solver, err := sut.Build(model.SolverTypeGenetic, tc.parameters)
+
+			// assert
+			if err != nil {
+				t.Fatalf("failed to build solver: %v", err)
+			}
+			got := solver.(*geneticSolver)
+			if got.maxPopulation != tc.want.maxPopulation || got.maxTime != tc.want.maxTime || got.mutationRate != tc.want.mutationRate {
+				t.Errorf("got (%d %v %f) want (%d %v %f)", got.maxPopulation, got.maxTime, got.mutationRate,
+					tc.want.maxPopulation, tc.want.maxTime, tc.want.mutationRate)
+			}
+			if got.operators != tc.want.operators {
+				t.Errorf("wrong operators: got %+v want %+v", got.operators, tc.want.operators)
+			}
+		})
+	}
+}
+
+func TestRegistryBuildIsland(t *testing.T) {
+	islands := 6
+	migrationInterval := 10
+	migrants := 3
+	threads := 2
+	maxGenerations := 500
+	cases := []struct {
+		desc string
+		parameters *model.SolverParameters
+		want *islandSolver
+		wantMaxGenerations int
+	}{
+		{ "defaults are used without parameters", nil, &islandSolver{
+			islands: defaultIslands,
+			migrationInterval: defaultMigrationInterval,
+			migrants: defaultMigrants,
+			threads: runtime.NumCPU(),
+		}, 0 },
+		{ "parameters override defaults", &model.SolverParameters{
+			Islands: &islands,
+			MigrationInterval: &migrationInterval,
+			Migrants: &migrants,
+			Threads: &threads,
+			MaxGenerations: &maxGenerations,
+		}, &islandSolver{
+			islands: islands,
+			migrationInterval: migrationInterval,
+			migrants: migrants,
+			threads: threads,
+		}, maxGenerations },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := NewDefaultRegistry(&factories.SolutionFactory{}, &factories.ZeroRandomFactory{})
+
+			// act
+			solver, err := sut.Build(model.SolverTypeIsland, tc.parameters)
+
+			// assert
+			if err != nil {
+				t.Fatalf("failed to build solver: %v", err)
+			}
+			got := solver.(*islandSolver)
+			if got.islands != tc.want.islands || got.migrationInterval != tc.want.migrationInterval || got.migrants != tc.want.migrants || got.threads != tc.want.threads {
+				t.Errorf("got (%d %d %d %d) want (%d %d %d %d)", got.islands, got.migrationInterval, got.migrants, got.threads,
+					tc.want.islands, tc.want.migrationInterval, tc.want.migrants, tc.want.threads)
+			}
+			if got.genetic.maxGenerations != tc.wantMaxGenerations {
+				t.Errorf("wrong maxGenerations: got %d want %d", got.genetic.maxGenerations, tc.wantMaxGenerations)
+			}
+		})
+	}
+}
+
+func TestRegistryBuildSimulatedAnnealing(t *testing.T) {
+	schedule := model.CoolingScheduleAdaptiveReheat
+	initialTemperature := 5.0
+	finalTemperature := 0.5
+	cases := []struct {
+		desc string
+		parameters *model.SolverParameters
+		want *simulatedAnnealingSolver
+	}{
+		{ "defaults are used without parameters", nil, &simulatedAnnealingSolver{
+			initialTemperature: defaultInitialTemperature,
+			finalTemperature: defaultFinalTemperature,
+			schedule: defaultCoolingSchedule,
+		} },
+		{ "parameters override defaults", &model.SolverParameters{
+			CoolingSchedule: &schedule,
+			InitialTemperature: &initialTemperature,
+			FinalTemperature: &finalTemperature,
+		}, &simulatedAnnealingSolver{
+			initialTemperature: initialTemperature,
+			finalTemperature: finalTemperature,
+			schedule: schedule,
+		} },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := NewDefaultRegistry(&factories.SolutionFactory{}, &factories.ZeroRandomFactory{})
+
+			// act
+			solver, err := sut.Build(model.SolverTypeSimulatedAnnealing, tc.parameters)
+
+			// assert
+			if err != nil {
+				t.Fatalf("failed to build solver: %v", err)
+			}
+			got := solver.(*simulatedAnnealingSolver)
+			if got.initialTemperature != tc.want.initialTemperature || got.finalTemperature != tc.want.finalTemperature || got.schedule != tc.want.schedule {
+				t.Errorf("got (%f %f %s) want (%f %f %s)", got.initialTemperature, got.finalTemperature, got.schedule,
+					tc.want.initialTemperature, tc.want.finalTemperature, tc.want.schedule)
+			}
+		})
+	}
+}
+
+func TestRegistryBuildTabu(t *testing.T) {
+	tenure := 25
+	aspiration := model.AspirationCriterionNone
+	cases := []struct {
+		desc string
+		parameters *model.SolverParameters
+		want *tabuSolver
+	}{
+		{ "defaults are used without parameters", nil, &tabuSolver{
+			tenure: defaultTabuTenure,
+			aspiration: defaultAspiration,
+		} },
+		{ "parameters override defaults", &model.SolverParameters{
+			TabuTenure: &tenure,
+			Aspiration: &aspiration,
+		}, &tabuSolver{
+			tenure: tenure,
+			aspiration: aspiration,
+		} },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := NewDefaultRegistry(&factories.SolutionFactory{}, &factories.ZeroRandomFactory{})
+
+			// act
+			solver, err := sut.Build(model.SolverTypeTabu, tc.parameters)
+
+			// assert
+			if err != nil {
+				t.Fatalf("failed to build solver: %v", err)
+			}
+			got := solver.(*tabuSolver)
+			if got.tenure != tc.want.tenure || got.aspiration != tc.want.aspiration {
+				t.Errorf("got (%d %s) want (%d %s)", got.tenure, got.aspiration, tc.want.tenure, tc.want.aspiration)
+			}
+		})
+	}
+}
+
+func TestRegistryBuildPortfolio(t *testing.T) {
+	cases := []struct {
+		desc string
+		parameters *model.SolverParameters
+		want []model.SolverType
+	}{
+		{ "default portfolio is used without parameters", nil, defaultPortfolio },
+		{ "parameters pick the members", &model.SolverParameters{
+			Portfolio: []model.SolverType{ model.SolverTypeTabu, model.SolverTypeDpll },
+		}, []model.SolverType{ model.SolverTypeTabu, model.SolverTypeDpll } },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := NewDefaultRegistry(&factories.SolutionFactory{}, &factories.ZeroRandomFactory{})
+
+			// act
+			solver, err := sut.Build(model.SolverTypePortfolio, tc.parameters)
+
+			// assert
+			if err != nil {
+				t.Fatalf("failed to build solver: %v", err)
+			}
+			got := solver.(*portfolioSolver)
+			if len(got.members) != len(tc.want) {
+				t.Fatalf("wrong number of members: got %d want %d", len(got.members), len(tc.want))
+			}
+			for index, member := range got.members {
+				if member.SolverType != tc.want[index] || member.Solver == nil {
+					t.Errorf("wrong member %d: got %+v want %s", index, member, tc.want[index])
+				}
+			}
+		})
+	}
+}
+
+func TestRegistryBuildWhenGivenInvalidInput(t *testing.T) {
+	noise := 1.5
+	maxTries := 0
+	tournamentSize := 1
+	largeTournamentSize := 11
+	tournament := model.SelectionOperatorTournament
+	islands := 0
+	populationSize := 200000
+	manyIslands := 65
+	threads := 1000
+	migrants := -1
+	refinementProbability := 2.0
+	zeroTemperature := 0.0
+	hotTemperature := 3.0
+	tabuTenure := -1
+	cases := []struct {
+		desc string
+		solverType model.SolverType
+		parameters *model.SolverParameters
+		err string
+	}{
+		{ "error on unregistered solver", model.SolverType("UNKNOWN"), nil, "no solver registered for UNKNOWN" },
+		{ "error on noise out of range", model.SolverTypeWalksat, &model.SolverParameters{ Noise: &noise }, "noise must be between 0 and 1, got 1.500000" },
+		{ "error on too few tries", model.SolverTypeGsat, &model.SolverParameters{ MaxTries: &maxTries }, "maxTries must be at least 1, got 0" },
+		{ "error on too small tournaments", model.SolverTypeGenetic, &model.SolverParameters{ TournamentSize: &tournamentSize }, "tournamentSize must be at least 2, got 1" },
+		{ "error on tournaments larger than the population", model.SolverTypeGenetic, &model.SolverParameters{ Selection: &tournament, TournamentSize: &largeTournamentSize }, "tournamentSize must be at most populationSize 10, got 11" },
+		{ "error on huge populations", model.SolverTypeGenetic, &model.SolverParameters{ PopulationSize: &populationSize }, "populationSize must be at most 10000, got 200000" },
+		{ "error on no islands", model.SolverTypeIsland, &model.SolverParameters{ Islands: &islands }, "islands must be at least 1, got 0" },
+		{ "error on too many islands", model.SolverTypeIsland, &model.SolverParameters{ Islands: &manyIslands }, "islands must be at most 64, got 65" },
+		{ "error on too many threads", model.SolverTypeIsland, &model.SolverParameters{ Threads: &threads }, "threads must be at most 256, got 1000" },
+		{ "error on negative migrants", model.SolverTypeIsland, &model.SolverParameters{ Migrants: &migrants }, "migrants must not be negative, got -1" },
+		{ "error on refinement probability out of range", model.SolverTypeGenetic, &model.SolverParameters{ RefinementProbability: &refinementProbability }, "refinementProbability must be between 0 and 1, got 2.000000" },
+		{ "error on zero initial temperature", model.SolverTypeSimulatedAnnealing, &model.SolverParameters{ InitialTemperature: &zeroTemperature }, "initialTemperature must be positive, got 0.000000" },
+		{ "error on final temperature above initial", model.SolverTypeSimulatedAnnealing, &model.SolverParameters{ FinalTemperature: &hotTemperature }, "finalTemperature must be positive and at most initialTemperature, got 3.000000" },
+		{ "error on negative tabu tenure", model.SolverTypeTabu, &model.SolverParameters{ TabuTenure: &tabuTenure }, "tabuTenure must not be negative, got -1" },
+		{ "error on empty portfolio", model.SolverTypePortfolio, &model.SolverParameters{ Portfolio: []model.SolverType{} }, "portfolio must not be empty" },
+		{ "error on nested portfolio", model.SolverTypePortfolio, &model.SolverParameters{ Portfolio: []model.SolverType{ model.SolverTypeCdcl, model.SolverTypePortfolio } }, "portfolio must not contain PORTFOLIO" },
+		{ "error on unregistered portfolio member", model.SolverTypePortfolio, &model.SolverParameters{ Portfolio: []model.SolverType{ model.SolverType("UNKNOWN") } }, "no solver registered for portfolio member UNKNOWN" },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := NewDefaultRegistry(&factories.SolutionFactory{}, &factories.ZeroRandomFactory{})
+
+			// act
+			solver, err := sut.Build(tc.solverType, tc.parameters)
+
+			// assert
+			if solver != nil {
+				t.Fatalf("got a solver when should be error")
+			}
+			if err.Error() != tc.err {
+				t.Errorf("got '%v' want '%v'", err, tc.err)
+			}
+		})
+	}
+}
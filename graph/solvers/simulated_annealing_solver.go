@@ -0,0 +1,211 @@
+package solvers
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+type simulatedAnnealingSolver struct {
+	maxTries int
+	maxFlips int
+	initialTemperature float64
+	finalTemperature float64
+	schedule model.CoolingSchedule
+	maxTime time.Duration
+	solutionFactory *factories.SolutionFactory
+	randomFactory factories.RandomFactory
+}
+
+func NewSimulatedAnnealingSolver(
+	maxTries int,
+	maxFlips int,
+	initialTemperature float64,
+	finalTemperature float64,
+	schedule model.CoolingSchedule,
+	maxTime time.Duration,
+	solutionFactory *factories.SolutionFactory,
+	randomFactory factories.RandomFactory,
+) *simulatedAnnealingSolver {
+	return &simulatedAnnealingSolver{
+		maxTries: maxTries,
+		maxFlips: maxFlips,
+		initialTemperature: initialTemperature,
+		finalTemperature: finalTemperature,
+		schedule: schedule,
+		maxTime: maxTime,
+		solutionFactory: solutionFactory,
+		randomFactory: randomFactory,
+	}
+}
+
+// Solve restarts from a random assignment up to maxTries times and anneals
+// each try over maxFlips steps. A step proposes flipping a random variable
+// and takes the flip if it leaves no more clauses unsatisfied, or otherwise
+// with probability exp(-delta/temperature), delta being how many more clauses
+// it leaves unsatisfied. Cycles count steps, whether their flip was taken or
+// not. Like the other local search solvers it can't prove a job
+// unsatisfiable, so running out of tries is reported as unknown.
+func (s *simulatedAnnealingSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, s.maxTime)
+	defer cancel()
+	random := s.randomFactory.Build()
+	search := newLocalSearch(job.Formula())
+	history := newHistoryRecorder(progressInterval)
+	reporter := progressReporter(ctx)
+	status := model.SolutionStatusUnknown
+	steps := 0
+	temperature := s.initialTemperature
+	best := make([]bool, len(search.values))
+	bestUnsatisfied := -1
+	reportedUnsatisfied := -1
+search:
+	for try := 0; try < s.maxTries; try++ {
+		search.randomize(random)
+		schedule := s.newSchedule()
+		tryUnsatisfied := len(search.unsatisfied) + 1
+		for step := 0; ; step++ {
+			if bestUnsatisfied < 0 || len(search.unsatisfied) < bestUnsatisfied {
+				copy(best, search.values)
+				bestUnsatisfied = len(search.unsatisfied)
+			}
+			if search.solved() || step >= s.maxFlips {
+				break
+			}
+			improved := len(search.unsatisfied) < tryUnsatisfied
+			if improved {
+				tryUnsatisfied = len(search.unsatisfied)
+			}
+			schedule.observe(step, improved)
+			temperature = schedule.temperature(step)
+			if steps % progressInterval == 0 {
+				if ctx.Err() != nil {
+					status = interruptedStatus(ctx)
+					break search
+				}
+				var incumbent map[string]bool
+				if reportedUnsatisfied < 0 || bestUnsatisfied < reportedUnsatisfied {
+					incumbent = search.formula.Assignment(best)
+					reportedUnsatisfied = bestUnsatisfied
+				}
+				progress := scoredProgress(steps, search.score(bestUnsatisfied), incumbent)
+				current := temperature
+				progress.Temperature = &current
+				reporter.Report(progress)
+				if history.due(steps) {
+					history.record(s.historyPoint(steps, search.score(bestUnsatisfied), temperature))
+				}
+			}
+			variable := random.Intn(len(search.values))
+			delta := search.breakCounts[variable] - search.makeCounts[variable]
+			if delta <= 0 || random.Float64() < math.Exp(-float64(delta) / temperature) {
+				search.flip(variable)
+			}
+			steps++
+		}
+		if search.solved() {
+			status = model.SolutionStatusSatisfiable
+			break
+		}
+	}
+	if bestUnsatisfied >= 0 {
+		history.finish(s.historyPoint(steps, search.score(bestUnsatisfied), temperature))
+	}
+	solution := s.solutionFactory.ConstructSolution(search.formula.Assignment(best), job, steps, time.Since(start), status)
+	solution.History = history.points
+	return solution
+}
+
+func (s *simulatedAnnealingSolver) historyPoint(steps int, bestScore float64, temperature float64) *model.HistoryPoint {
+	return &model.HistoryPoint{
+		Cycle: steps,
+		BestScore: bestScore,
+		Temperature: &temperature,
+	}
+}
+
+func (s *simulatedAnnealingSolver) newSchedule() coolingSchedule {
+	switch s.schedule {
+	case model.CoolingScheduleLinear:
+		return &linearCooling{initial: s.initialTemperature, final: s.finalTemperature, steps: s.maxFlips}
+	case model.CoolingScheduleAdaptiveReheat:
+		return &reheatingCooling{
+			geometricCooling: geometricCooling{initial: s.initialTemperature, final: s.finalTemperature, steps: s.maxFlips},
+			patience: int(math.Max(1, float64(s.maxFlips) * reheatPatience)),
+			offset: 1,
+		}
+	}
+	return &geometricCooling{initial: s.initialTemperature, final: s.finalTemperature, steps: s.maxFlips}
+}
+
+// reheatPatience is the fraction of a try the adaptive reheat schedule waits
+// for the try's best score to improve before it reheats.
+const reheatPatience = 0.1
+
+// coolingSchedule gives the temperature at each step of a try. observe is
+// told at every step whether the try has just reached its best score so far,
+// for schedules that react to it.
+type coolingSchedule interface {
+	temperature(step int) float64
+	observe(step int, improved bool)
+}
+
+// geometricCooling multiplies the temperature by the same ratio at every
+// step, going from initial to final over steps steps.
+type geometricCooling struct {
+	initial float64
+	final float64
+	steps int
+}
+
+func (c *geometricCooling) temperature(step int) float64 {
+	return c.initial * math.Pow(c.final / c.initial, float64(step) / float64(c.steps))
+}
+
+func (c *geometricCooling) observe(step int, improved bool) {
+}
+
+// linearCooling lowers the temperature by the same amount at every step.
+type linearCooling struct {
+	initial float64
+	final float64
+	steps int
+}
+
+func (c *linearCooling) temperature(step int) float64 {
+	return c.initial - (c.initial - c.final) * float64(step) / float64(c.steps)
+}
+
+func (c *linearCooling) observe(step int, improved bool) {
+}
+
+// reheatingCooling cools geometrically, but whenever the best score hasn't
+// improved for patience steps it climbs back to the temperature halfway
+// between the current and the initial one and cools down from there at the
+// same rate. offset scales the geometric temperature by all reheats so far.
+type reheatingCooling struct {
+	geometricCooling
+	patience int
+	lastImprovement int
+	offset float64
+}
+
+func (c *reheatingCooling) temperature(step int) float64 {
+	return c.geometricCooling.temperature(step) * c.offset
+}
+
+func (c *reheatingCooling) observe(step int, improved bool) {
+	if improved {
+		c.lastImprovement = step
+		return
+	}
+	if step - c.lastImprovement >= c.patience {
+		current := c.temperature(step)
+		c.offset *= (current + c.initial) / 2 / current
+		c.lastImprovement = step
+	}
+}
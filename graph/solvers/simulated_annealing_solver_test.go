@@ -0,0 +1,146 @@
+package solvers
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestSimulatedAnnealingSolve(t *testing.T) {
+	maxTime, _ := time.ParseDuration("10s")
+	cases := []struct {
+		desc string
+		schedule model.CoolingSchedule
+	}{
+		{ "geometric cooling solves a planted job", model.CoolingScheduleGeometric },
+		{ "linear cooling solves a planted job", model.CoolingScheduleLinear },
+		{ "adaptive reheat solves a planted job", model.CoolingScheduleAdaptiveReheat },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			job := plantedJob(rand.New(rand.NewSource(0)), 200, 800)
+			sut := NewSimulatedAnnealingSolver(10, 100000, 2.0, 0.05, tc.schedule, maxTime, &factories.SolutionFactory{}, &factories.SeededRandomFactory{Seed: 1})
+
+			// act
+			got := sut.Solve(context.Background(), job)
+
+			// assert
+			assertStatusesAreEqual(t, got, model.SolutionStatusSatisfiable)
+			if got.Score != 1.0 {
+				t.Errorf("wrong score: got %f want 1.0", got.Score)
+			}
+		})
+	}
+}
+
+func TestSimulatedAnnealingGivesUp(t *testing.T) {
+	// arrange
+	maxTime, _ := time.ParseDuration("10s")
+	sut := NewSimulatedAnnealingSolver(2, 1000, 2.0, 0.05, model.CoolingScheduleGeometric, maxTime, &factories.SolutionFactory{}, &factories.ZeroRandomFactory{})
+
+	// act
+	got := sut.Solve(context.Background(), bigUnsolvableJob(rand.New(rand.NewSource(0))))
+
+	// assert
+	assertStatusesAreEqual(t, got, model.SolutionStatusUnknown)
+	if got.Cycles != 2000 {
+		t.Errorf("wrong number of cycles: got %d want %d", got.Cycles, 2000)
+	}
+}
+
+func TestSimulatedAnnealingReportsTemperature(t *testing.T) {
+	// arrange
+	maxTime, _ := time.ParseDuration("10s")
+	job := randomJob(rand.New(rand.NewSource(0)), 300, 1400)
+	reporter := &recordingReporter{}
+	ctx := WithProgressReporter(context.Background(), reporter)
+	sut := NewSimulatedAnnealingSolver(1, 50000, 2.0, 0.05, model.CoolingScheduleGeometric, maxTime, &factories.SolutionFactory{}, &factories.SeededRandomFactory{Seed: 1})
+
+	// act
+	got := sut.Solve(ctx, job)
+
+	// assert
+	if len(reporter.reports) == 0 {
+		t.Fatalf("failed to report progress")
+	}
+	for index, report := range reporter.reports {
+		if report.Temperature == nil {
+			t.Fatalf("failed to report the temperature")
+		}
+		if index > 0 && *report.Temperature >= *reporter.reports[index - 1].Temperature {
+			t.Errorf("temperature failed to fall: got %f after %f", *report.Temperature, *reporter.reports[index - 1].Temperature)
+		}
+	}
+	for _, point := range got.History {
+		if point.Temperature == nil || *point.Temperature < 0.05 || *point.Temperature > 2.0 {
+			t.Errorf("history temperature out of range: got %v", point.Temperature)
+		}
+	}
+}
+
+func TestCoolingSchedules(t *testing.T) {
+	cases := []struct {
+		desc string
+		sut coolingSchedule
+		step int
+		want float64
+	}{
+		{ "geometric starts at the initial temperature", &geometricCooling{initial: 2.0, final: 0.02, steps: 100}, 0, 2.0 },
+		{ "geometric halfway is the geometric mean", &geometricCooling{initial: 2.0, final: 0.02, steps: 100}, 50, 0.2 },
+		{ "geometric ends at the final temperature", &geometricCooling{initial: 2.0, final: 0.02, steps: 100}, 100, 0.02 },
+		{ "linear starts at the initial temperature", &linearCooling{initial: 2.0, final: 0.02, steps: 100}, 0, 2.0 },
+		{ "linear halfway is the arithmetic mean", &linearCooling{initial: 2.0, final: 0.02, steps: 100}, 50, 1.01 },
+		{ "linear ends at the final temperature", &linearCooling{initial: 2.0, final: 0.02, steps: 100}, 100, 0.02 },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// act
+			got := tc.sut.temperature(tc.step)
+
+			// assert
+			if math.Abs(got - tc.want) > 1e-9 {
+				t.Errorf("wrong temperature: got %f want %f", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReheatingCooling(t *testing.T) {
+	cases := []struct {
+		desc string
+		improvements []bool
+		want float64
+	}{
+		{ "cools geometrically while patient", stagnation(9), 2.0 * math.Pow(0.01, 0.09) },
+		{ "reheats halfway to the initial temperature once patience runs out", stagnation(11), (2.0 + 2.0 * math.Pow(0.01, 0.1)) / 2 * math.Pow(0.01, 0.01) },
+		{ "waits for full patience after improvement", append(append(stagnation(5), true), stagnation(5)...), 2.0 * math.Pow(0.01, 0.11) },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := &reheatingCooling{
+				geometricCooling: geometricCooling{initial: 2.0, final: 0.02, steps: 100},
+				patience: 10,
+				offset: 1,
+			}
+			sut.observe(0, true)
+
+			// act
+			for step, improved := range tc.improvements {
+				sut.observe(step + 1, improved)
+			}
+			got := sut.temperature(len(tc.improvements))
+
+			// assert
+			if math.Abs(got - tc.want) > 1e-9 {
+				t.Errorf("wrong temperature: got %f want %f", got, tc.want)
+			}
+		})
+	}
+}
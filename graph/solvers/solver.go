@@ -1,7 +1,25 @@
 package solvers
 
-import "github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+import (
+	"context"
+	"errors"
 
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+// Solver implementations must return their best solution so far, marked
+// CANCELLED, soon after ctx is cancelled. While solving they report their
+// progress, including every improved incumbent if they keep a complete
+// assignment, to the ProgressReporter given with WithProgressReporter.
 type Solver interface {
-	Solve(job *model.Job) *model.Solution
+	Solve(ctx context.Context, job *model.Job) *model.Solution
+}
+
+// interruptedStatus reports why a solver had to stop before reaching a
+// verdict: either its context was cancelled or its time limit ran out.
+func interruptedStatus(ctx context.Context) model.SolutionStatus {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return model.SolutionStatusCancelled
+	}
+	return model.SolutionStatusTimeout
 }
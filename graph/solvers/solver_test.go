@@ -0,0 +1,241 @@
+package solvers
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	u "github.com/google/uuid"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestSolveWhenCancelled(t *testing.T) {
+	maxTime, _ := time.ParseDuration("10s")
+	factory := &factories.SolutionFactory{}
+	randomFactory := &factories.ZeroRandomFactory{}
+	cases := []struct {
+		desc string
+		sut Solver
+	}{
+		{ "naive solver stops", NewNaiveSolver(factory) },
+		{ "genetic solver stops", NewGeneticSolver(10, maxTime, 0, 0.01, GeneticOperators{}, factory, NewPopulationGenerator(randomFactory), randomFactory) },
+		{ "island solver stops", NewIslandSolver(NewGeneticSolver(10, maxTime, 0, 0.01, GeneticOperators{}, factory, NewPopulationGenerator(randomFactory), randomFactory), 4, 50, 2, 2) },
+		{ "dpll solver stops", NewDpllSolver(maxTime, factory) },
+		{ "cdcl solver stops", NewCdclSolver(maxTime, factory) },
+		{ "walksat solver stops", NewWalkSatSolver(10, 100000, 0.5, maxTime, factory, randomFactory) },
+		{ "gsat solver stops", NewGsatSolver(10, 100000, 0.5, maxTime, factory, randomFactory) },
+		{ "simulated annealing solver stops", NewSimulatedAnnealingSolver(10, 100000, 2.0, 0.05, model.CoolingScheduleGeometric, maxTime, factory, randomFactory) },
+		{ "tabu solver stops", NewTabuSolver(10, 100000, 10, model.AspirationCriterionBest, maxTime, factory, randomFactory) },
+		{ "portfolio solver stops", NewPortfolioSolver([]PortfolioMember{
+				{ SolverType: model.SolverTypeCdcl, Solver: NewCdclSolver(maxTime, factory) },
+				{ SolverType: model.SolverTypeWalksat, Solver: NewWalkSatSolver(10, 100000, 0.5, maxTime, factory, randomFactory) },
+			}, maxTime, factory) },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			job := randomJob(rand.New(rand.NewSource(0)), 300, 1400)
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			// act
+			got := tc.sut.Solve(ctx, job)
+
+			// assert
+			assertStatusesAreEqual(t, got, model.SolutionStatusCancelled)
+			if len(got.Variables) != 300 {
+				t.Errorf("failed to return best assignment so far: got %d variables want %d", len(got.Variables), 300)
+			}
+		})
+	}
+}
+
+type recordingReporter struct {
+	reports []Progress
+}
+
+func (r *recordingReporter) Report(progress Progress) {
+	r.reports = append(r.reports, progress)
+}
+
+func TestSolveReportsProgress(t *testing.T) {
+	maxTime, _ := time.ParseDuration("300ms")
+	factory := &factories.SolutionFactory{}
+	randomFactory := &factories.SeededRandomFactory{Seed: 1}
+	cases := []struct {
+		desc string
+		sut Solver
+		scored bool
+	}{
+		{ "genetic solver reports", NewGeneticSolver(10, maxTime, 0, 0.01, GeneticOperators{}, factory, NewPopulationGenerator(randomFactory), randomFactory), true },
+		{ "island solver reports", NewIslandSolver(NewGeneticSolver(10, maxTime, 0, 0.01, GeneticOperators{}, factory, NewPopulationGenerator(randomFactory), randomFactory), 4, 50, 2, 2), true },
+		{ "dpll solver reports", NewDpllSolver(maxTime, factory), false },
+		{ "cdcl solver reports", NewCdclSolver(maxTime, factory), false },
+		{ "walksat solver reports", NewWalkSatSolver(10, 100000, 0.5, maxTime, factory, randomFactory), true },
+		{ "gsat solver reports", NewGsatSolver(10, 100000, 0.5, maxTime, factory, randomFactory), true },
+		{ "simulated annealing solver reports", NewSimulatedAnnealingSolver(10, 100000, 2.0, 0.05, model.CoolingScheduleGeometric, maxTime, factory, randomFactory), true },
+		{ "tabu solver reports", NewTabuSolver(10, 100000, 10, model.AspirationCriterionBest, maxTime, factory, randomFactory), true },
+		{ "portfolio solver reports", NewPortfolioSolver([]PortfolioMember{
+				{ SolverType: model.SolverTypeWalksat, Solver: NewWalkSatSolver(10, 100000, 0.5, maxTime, factory, &factories.SeededRandomFactory{Seed: 1}) },
+				{ SolverType: model.SolverTypeGsat, Solver: NewGsatSolver(10, 100000, 0.5, maxTime, factory, &factories.SeededRandomFactory{Seed: 2}) },
+			}, maxTime, factory), true },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			job := randomJob(rand.New(rand.NewSource(0)), 300, 1400)
+			reporter := &recordingReporter{}
+			ctx := WithProgressReporter(context.Background(), reporter)
+
+			// act
+			got := tc.sut.Solve(ctx, job)
+
+			// assert
+			if len(reporter.reports) == 0 {
+				t.Fatalf("failed to report progress")
+			}
+			if tc.scored && reporter.reports[0].Best == nil {
+				t.Errorf("failed to report the first incumbent")
+			}
+			for index, report := range reporter.reports {
+				if index > 0 && report.Cycles <= reporter.reports[index - 1].Cycles {
+					t.Errorf("cycles went backwards: got %d after %d", report.Cycles, reporter.reports[index - 1].Cycles)
+				}
+				if (report.BestScore != nil) != tc.scored {
+					t.Fatalf("wrong best score presence: got %v want %t", report.BestScore, tc.scored)
+				}
+				if tc.scored && *report.BestScore > got.Score {
+					t.Errorf("reported a better score than the solution: got %f want at most %f", *report.BestScore, got.Score)
+				}
+				if report.Best != nil && job.Score(report.Best) != *report.BestScore {
+					t.Errorf("incumbent does not match best score: got %f want %f", job.Score(report.Best), *report.BestScore)
+				}
+			}
+		})
+	}
+}
+
+func TestSolveRecordsHistory(t *testing.T) {
+	maxTime, _ := time.ParseDuration("100ms")
+	factory := &factories.SolutionFactory{}
+	randomFactory := &factories.SeededRandomFactory{Seed: 1}
+	cases := []struct {
+		desc string
+		sut Solver
+		traced bool
+		population bool
+	}{
+		{ "genetic solver traces its population", NewGeneticSolver(10, maxTime, 0, 0.01, GeneticOperators{}, factory, NewPopulationGenerator(randomFactory), randomFactory), true, true },
+		{ "island solver traces its populations", NewIslandSolver(NewGeneticSolver(10, maxTime, 0, 0.01, GeneticOperators{}, factory, NewPopulationGenerator(randomFactory), randomFactory), 4, 50, 2, 2), true, true },
+		{ "dpll solver has no trace", NewDpllSolver(maxTime, factory), false, false },
+		{ "cdcl solver has no trace", NewCdclSolver(maxTime, factory), false, false },
+		{ "walksat solver traces its best score", NewWalkSatSolver(10, 100000, 0.5, maxTime, factory, randomFactory), true, false },
+		{ "gsat solver traces its best score", NewGsatSolver(10, 100000, 0.5, maxTime, factory, randomFactory), true, false },
+		{ "simulated annealing solver traces its best score", NewSimulatedAnnealingSolver(10, 100000, 2.0, 0.05, model.CoolingScheduleGeometric, maxTime, factory, randomFactory), true, false },
+		{ "tabu solver traces its best score", NewTabuSolver(10, 100000, 10, model.AspirationCriterionBest, maxTime, factory, randomFactory), true, false },
+		{ "portfolio solver traces its winner", NewPortfolioSolver([]PortfolioMember{
+				{ SolverType: model.SolverTypeWalksat, Solver: NewWalkSatSolver(10, 100000, 0.5, maxTime, factory, &factories.SeededRandomFactory{Seed: 1}) },
+				{ SolverType: model.SolverTypeGsat, Solver: NewGsatSolver(10, 100000, 0.5, maxTime, factory, &factories.SeededRandomFactory{Seed: 2}) },
+			}, maxTime, factory), true, false },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			job := randomJob(rand.New(rand.NewSource(0)), 300, 1400)
+
+			// act
+			got := tc.sut.Solve(context.Background(), job)
+
+			// assert
+			if !tc.traced {
+				if len(got.History) != 0 {
+					t.Errorf("wrong number of history points: got %d want 0", len(got.History))
+				}
+				return
+			}
+			if len(got.History) < 2 || len(got.History) > maxHistory + 1 {
+				t.Fatalf("wrong number of history points: got %d", len(got.History))
+			}
+			last := got.History[len(got.History) - 1]
+			if last.Cycle != got.Cycles || last.BestScore != got.Score {
+				t.Errorf("history does not end on the solution: got (%d %f) want (%d %f)", last.Cycle, last.BestScore, got.Cycles, got.Score)
+			}
+			for index, point := range got.History {
+				if index > 0 && (point.Cycle <= got.History[index - 1].Cycle || point.BestScore < got.History[index - 1].BestScore) {
+					t.Errorf("history point %d does not follow the previous one: got %+v after %+v", index, *point, *got.History[index - 1])
+				}
+				if (point.AverageScore != nil) != tc.population || (point.Diversity != nil) != tc.population {
+					t.Fatalf("wrong population statistics presence: got %+v want %t", *point, tc.population)
+				}
+				if tc.population && (*point.AverageScore > point.BestScore || *point.Diversity < 0 || *point.Diversity > 1) {
+					t.Errorf("population statistics out of range: got %+v", *point)
+				}
+			}
+		})
+	}
+}
+
+func TestSolveMixedWidthClauses(t *testing.T) {
+	maxTime, _ := time.ParseDuration("10s")
+	factory := &factories.SolutionFactory{}
+	randomFactory := &factories.SeededRandomFactory{Seed: 1}
+	cases := []struct {
+		desc string
+		sut Solver
+		complete bool
+	}{
+		{ "dpll solver", NewDpllSolver(maxTime, factory), true },
+		{ "cdcl solver", NewCdclSolver(maxTime, factory), true },
+		{ "walksat solver", NewWalkSatSolver(10, 10000, 0.5, maxTime, factory, randomFactory), false },
+		{ "gsat solver", NewGsatSolver(10, 10000, 0.5, maxTime, factory, randomFactory), false },
+		{ "simulated annealing solver", NewSimulatedAnnealingSolver(10, 10000, 2.0, 0.05, model.CoolingScheduleGeometric, maxTime, factory, randomFactory), false },
+		{ "tabu solver", NewTabuSolver(10, 10000, 10, model.AspirationCriterionBest, maxTime, factory, randomFactory), false },
+		{ "portfolio solver", NewPortfolioSolver([]PortfolioMember{
+				{ SolverType: model.SolverTypeWalksat, Solver: NewWalkSatSolver(10, 10000, 0.5, maxTime, factory, randomFactory) },
+				{ SolverType: model.SolverTypeCdcl, Solver: NewCdclSolver(maxTime, factory) },
+			}, maxTime, factory), true },
+	}
+	for _, tc := range cases {
+		random := rand.New(rand.NewSource(0))
+		for i := 0; i < 100; i++ {
+			job := randomMixedWidthJob(random, 2 + random.Intn(7), 1 + random.Intn(30))
+			t.Run(fmt.Sprintf("%s on random job %d", tc.desc, i), func(t *testing.T) {
+				// arrange
+				want := bruteForceSatisfiable(job)
+
+				// act
+				got := tc.sut.Solve(context.Background(), job)
+
+				// assert
+				if want && got.Score != 1.0 {
+					t.Errorf("failed to solve satisfiable job: score %f", got.Score)
+				}
+				if !want && got.Score == 1.0 {
+					t.Errorf("solved unsatisfiable job")
+				}
+				if tc.complete {
+					assertStatusesAreEqual(t, got, bruteForceStatus(want))
+				}
+			})
+		}
+	}
+}
+
+func randomMixedWidthJob(random *rand.Rand, variables int, clauses int) *model.Job {
+	job := &model.Job{
+		Name: u.NewString(),
+		Clauses: []*model.Clause{},
+	}
+	for i := 0; i < clauses; i++ {
+		clause := &model.Clause{ Literals: []*model.Variable{} }
+		for width := 1 + random.Intn(5); width > 0; width-- {
+			clause.Literals = append(clause.Literals, &model.Variable{ Name: fmt.Sprintf("v%d", random.Intn(variables)), Negated: random.Intn(2) == 0 })
+		}
+		job.Clauses = append(job.Clauses, clause)
+	}
+	return job
+}
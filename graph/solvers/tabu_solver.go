@@ -0,0 +1,121 @@
+package solvers
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+type tabuSolver struct {
+	maxTries int
+	maxFlips int
+	tenure int
+	aspiration model.AspirationCriterion
+	maxTime time.Duration
+	solutionFactory *factories.SolutionFactory
+	randomFactory factories.RandomFactory
+}
+
+func NewTabuSolver(
+	maxTries int,
+	maxFlips int,
+	tenure int,
+	aspiration model.AspirationCriterion,
+	maxTime time.Duration,
+	solutionFactory *factories.SolutionFactory,
+	randomFactory factories.RandomFactory,
+) *tabuSolver {
+	return &tabuSolver{
+		maxTries: maxTries,
+		maxFlips: maxFlips,
+		tenure: tenure,
+		aspiration: aspiration,
+		maxTime: maxTime,
+		solutionFactory: solutionFactory,
+		randomFactory: randomFactory,
+	}
+}
+
+func (s *tabuSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, s.maxTime)
+	defer cancel()
+	random := s.randomFactory.Build()
+	search := newLocalSearch(job.Formula())
+	tabu := &tabuList{
+		tenure: s.tenure,
+		aspiration: s.aspiration,
+		expiries: make([]int, len(search.values)),
+		bestUnsatisfied: -1,
+	}
+	history := newHistoryRecorder(progressInterval)
+	best, flips, status := search.run(ctx, s.maxTries, s.maxFlips, random, tabu.pickVariable, history)
+	solution := s.solutionFactory.ConstructSolution(best, job, flips, time.Since(start), status)
+	solution.History = history.points
+	return solution
+}
+
+// tabuList remembers the flip after which each variable may be flipped
+// again, tenure flips after it was last flipped. It outlives restarts, so a
+// new try may begin with a few variables still tabu.
+type tabuList struct {
+	tenure int
+	aspiration model.AspirationCriterion
+	expiries []int
+	flips int
+	bestUnsatisfied int
+}
+
+// pickVariable greedily picks the variable whose flip gives the largest net
+// gain in satisfied clauses, breaking ties randomly, out of those that aren't
+// tabu or whose flip meets the aspiration criterion. When every variable is
+// tabu it picks one at random.
+func (t *tabuList) pickVariable(search *localSearch, random *rand.Rand) int {
+	if t.bestUnsatisfied < 0 || len(search.unsatisfied) < t.bestUnsatisfied {
+		t.bestUnsatisfied = len(search.unsatisfied)
+	}
+	candidates := []int{}
+	bestGain := 0
+	for variable := range search.values {
+		gain := search.makeCounts[variable] - search.breakCounts[variable]
+		if !t.allowed(variable, gain, len(search.unsatisfied)) {
+			continue
+		}
+		if len(candidates) == 0 || gain > bestGain {
+			candidates = candidates[:0]
+			bestGain = gain
+		}
+		if gain == bestGain {
+			candidates = append(candidates, variable)
+		}
+	}
+	var variable int
+	if len(candidates) == 0 {
+		variable = random.Intn(len(search.values))
+	} else {
+		variable = candidates[random.Intn(len(candidates))]
+	}
+	t.flips++
+	t.expiries[variable] = t.flips + t.tenure
+	return variable
+}
+
+// allowed tells whether a variable may be flipped, given the net gain of the
+// flip and how many clauses are unsatisfied now. The BEST criterion lifts the
+// tabu for a flip that beats the best assignment found so far, IMPROVING for
+// any flip that beats the current one and NONE never.
+func (t *tabuList) allowed(variable int, gain int, unsatisfied int) bool {
+	if t.expiries[variable] <= t.flips {
+		return true
+	}
+	switch t.aspiration {
+	case model.AspirationCriterionBest:
+		return unsatisfied - gain < t.bestUnsatisfied
+	case model.AspirationCriterionImproving:
+		return gain > 0
+	}
+	return false
+}
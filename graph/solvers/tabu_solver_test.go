@@ -0,0 +1,121 @@
+package solvers
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+func TestTabuSolve(t *testing.T) {
+	maxTime, _ := time.ParseDuration("10s")
+	cases := []struct {
+		desc string
+		aspiration model.AspirationCriterion
+	}{
+		{ "best aspiration solves a planted job", model.AspirationCriterionBest },
+		{ "improving aspiration solves a planted job", model.AspirationCriterionImproving },
+		{ "no aspiration solves a planted job", model.AspirationCriterionNone },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			job := plantedJob(rand.New(rand.NewSource(0)), 200, 800)
+			sut := NewTabuSolver(10, 100000, 10, tc.aspiration, maxTime, &factories.SolutionFactory{}, &factories.SeededRandomFactory{Seed: 1})
+
+			// act
+			got := sut.Solve(context.Background(), job)
+
+			// assert
+			assertStatusesAreEqual(t, got, model.SolutionStatusSatisfiable)
+			if got.Score != 1.0 {
+				t.Errorf("wrong score: got %f want 1.0", got.Score)
+			}
+		})
+	}
+}
+
+func TestTabuGivesUp(t *testing.T) {
+	// arrange
+	maxTime, _ := time.ParseDuration("10s")
+	sut := NewTabuSolver(2, 1000, 10, model.AspirationCriterionBest, maxTime, &factories.SolutionFactory{}, &factories.ZeroRandomFactory{})
+
+	// act
+	got := sut.Solve(context.Background(), bigUnsolvableJob(rand.New(rand.NewSource(0))))
+
+	// assert
+	assertStatusesAreEqual(t, got, model.SolutionStatusUnknown)
+	if got.Cycles != 2000 {
+		t.Errorf("wrong number of cycles: got %d want %d", got.Cycles, 2000)
+	}
+}
+
+func TestTabuAllowed(t *testing.T) {
+	cases := []struct {
+		desc string
+		aspiration model.AspirationCriterion
+		variable int
+		gain int
+		want bool
+	}{
+		{ "allows a variable whose tenure has run out", model.AspirationCriterionNone, 0, -1, true },
+		{ "forbids a tabu variable without aspiration", model.AspirationCriterionNone, 1, 3, false },
+		{ "best aspiration allows a flip beating the best assignment", model.AspirationCriterionBest, 1, 3, true },
+		{ "best aspiration forbids a flip only beating the current assignment", model.AspirationCriterionBest, 1, 2, false },
+		{ "improving aspiration allows a flip beating the current assignment", model.AspirationCriterionImproving, 1, 1, true },
+		{ "improving aspiration forbids a flip that gains nothing", model.AspirationCriterionImproving, 1, 0, false },
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// arrange
+			sut := &tabuList{
+				tenure: 10,
+				aspiration: tc.aspiration,
+				expiries: []int{ 5, 12 },
+				flips: 5,
+				bestUnsatisfied: 3,
+			}
+
+			// act
+			got := sut.allowed(tc.variable, tc.gain, 5)
+
+			// assert
+			if got != tc.want {
+				t.Errorf("got %t want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTabuKeepsFlippedVariables(t *testing.T) {
+	// arrange
+	job := randomJob(rand.New(rand.NewSource(0)), 50, 200)
+	search := newLocalSearch(job.Formula())
+	random := rand.New(rand.NewSource(1))
+	search.randomize(random)
+	tenure := 10
+	sut := &tabuList{
+		tenure: tenure,
+		aspiration: model.AspirationCriterionNone,
+		expiries: make([]int, len(search.values)),
+		bestUnsatisfied: -1,
+	}
+	flipped := []int{}
+
+	for flip := 0; flip < 100; flip++ {
+		// act
+		variable := sut.pickVariable(search, random)
+		search.flip(variable)
+
+		// assert
+		for recent := len(flipped) - 1; recent >= 0 && recent >= len(flipped) - tenure; recent-- {
+			if flipped[recent] == variable {
+				t.Fatalf("flipped variable %d again %d flips later", variable, len(flipped) - recent)
+			}
+		}
+		flipped = append(flipped, variable)
+	}
+}
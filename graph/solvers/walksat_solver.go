@@ -0,0 +1,79 @@
+package solvers
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
+)
+
+type walkSatSolver struct {
+	maxTries int
+	maxFlips int
+	noise float64
+	maxTime time.Duration
+	solutionFactory *factories.SolutionFactory
+	randomFactory factories.RandomFactory
+}
+
+func NewWalkSatSolver(
+	maxTries int,
+	maxFlips int,
+	noise float64,
+	maxTime time.Duration,
+	solutionFactory *factories.SolutionFactory,
+	randomFactory factories.RandomFactory,
+) *walkSatSolver {
+	return &walkSatSolver{
+		maxTries: maxTries,
+		maxFlips: maxFlips,
+		noise: noise,
+		maxTime: maxTime,
+		solutionFactory: solutionFactory,
+		randomFactory: randomFactory,
+	}
+}
+
+func (s *walkSatSolver) Solve(ctx context.Context, job *model.Job) *model.Solution {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, s.maxTime)
+	defer cancel()
+	random := s.randomFactory.Build()
+	search := newLocalSearch(job.Formula())
+	history := newHistoryRecorder(progressInterval)
+	best, flips, status := search.run(ctx, s.maxTries, s.maxFlips, random, s.pickVariable, history)
+	solution := s.solutionFactory.ConstructSolution(best, job, flips, time.Since(start), status)
+	solution.History = history.points
+	return solution
+}
+
+func (s *walkSatSolver) pickVariable(search *localSearch, random *rand.Rand) int {
+	return walkSatPick(search, random, s.noise)
+}
+
+// walkSatPick chooses a variable from a random unsatisfied clause: one that
+// breaks no other clause if possible, otherwise a random one with probability
+// noise and the one breaking the fewest clauses the rest of the time.
+func walkSatPick(search *localSearch, random *rand.Rand, noise float64) int {
+	clause := search.randomUnsatisfiedClause(random)
+	candidates := []int{}
+	fewestBreaks := -1
+	for _, literal := range clause {
+		variable, _ := decodeLiteral(literal)
+		breaks := search.breakCounts[variable]
+		if fewestBreaks < 0 || breaks < fewestBreaks {
+			candidates = candidates[:0]
+			fewestBreaks = breaks
+		}
+		if breaks == fewestBreaks {
+			candidates = append(candidates, variable)
+		}
+	}
+	if fewestBreaks > 0 && random.Float64() < noise {
+		variable, _ := decodeLiteral(clause[random.Intn(len(clause))])
+		return variable
+	}
+	return candidates[random.Intn(len(candidates))]
+}
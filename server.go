@@ -4,13 +4,16 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"time"
+	"runtime"
+	"strconv"
+	"strings"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph"
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph/factories"
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph/generated"
+	"github.com/tgrindinger/go-graphql-3sat-solver/graph/model"
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph/repositories"
 	"github.com/tgrindinger/go-graphql-3sat-solver/graph/solvers"
 )
@@ -23,31 +26,57 @@ func main() {
 		port = defaultPort
 	}
 
-	resolver := buildResolver()
+	workers := runtime.NumCPU()
+	if value := os.Getenv("WORKERS"); value != "" {
+		var err error
+		workers, err = strconv.Atoi(value)
+		if err != nil || workers < 1 {
+			log.Fatalf("invalid WORKERS value '%s'", value)
+		}
+	}
+
+	resolver := buildResolver(os.Getenv("SOLVER"), workers)
 	srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: resolver}))
 
 	http.Handle("/", playground.Handler("GraphQL playground", "/query"))
 	http.Handle("/query", srv)
+	http.Handle(graph.SolutionPath, &graph.SolutionHandler{JobDispatcher: resolver.JobDispatcher})
 
 	log.Printf("connect to http://localhost:%s/ for GraphQL playground", port)
 	log.Fatal(http.ListenAndServe(":"+port, nil))
 }
 
-func buildResolver() *graph.Resolver {
+func buildResolver(solverName string, workers int) *graph.Resolver {
 	jobRepository := repositories.NewSqliteJobRepository("jobs.db")
-	solutionRepository := &repositories.InMemorySolutionRepository{}
-	jobFactory := &factories.JobFactory{}
+	solutionRepository := repositories.NewSqliteSolutionRepository("jobs.db")
+	jobFactory := &factories.JobFactory{DefaultSolver: defaultSolver(solverName)}
 	solutionFactory := &factories.SolutionFactory{}
-	duration, _ := time.ParseDuration("10s")
 	randomFactory := &factories.TimeRandomFactory{}
-	populationGenerator := solvers.NewPopulationGenerator(randomFactory)
-	solver := solvers.NewGeneticSolver(10, duration, solutionFactory, populationGenerator, randomFactory)
+	registry := solvers.NewDefaultRegistry(solutionFactory, randomFactory)
+	jobDispatcher := graph.NewJobDispatcher(
+		registry,
+		jobRepository,
+		solutionRepository,
+		jobFactory,
+		solutionFactory,
+		workers,
+	)
+	err := jobDispatcher.RecoverJobs()
+	if err != nil {
+		log.Fatalf("unable to recover unfinished jobs: %v", err)
+	}
 	return &graph.Resolver{
-		JobDispatcher: graph.NewJobDispatcher(
-			solver,
-			jobRepository,
-			solutionRepository,
-			jobFactory,
-		),
+		JobDispatcher: jobDispatcher,
+	}
+}
+
+func defaultSolver(solverName string) model.SolverType {
+	if solverName == "" {
+		return model.SolverTypeGenetic
+	}
+	solverType := model.SolverType(strings.ToUpper(solverName))
+	if !solverType.IsValid() {
+		log.Fatalf("unknown solver '%s'", solverName)
 	}
+	return solverType
 }